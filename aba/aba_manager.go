@@ -0,0 +1,536 @@
+package aba
+
+import(
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"distry/membership"
+	"distry/messages"
+	genmsg "distry/proto_gen/messages"
+	"distry/rbc0"
+)
+
+//types of aba messages, see proto/messages.proto
+const(
+	typeBval		uint32 = 1
+	typeAux		uint32 = 2
+	typeTerm		uint32 = 3
+)
+
+//BVALs and AUXs are kept for the rounds from the one this node is in up to roundWindow rounds ahead,
+//later rounds are dropped so their messages can't grow a session without bound.
+//a node which lags that far behind catches up on the TERMs of the nodes which decided
+const roundWindow = 16
+
+//a coin which failed to toss is tossed again after coinRetryDelay, the messages which would
+//have made this node toss it arrived already
+const coinRetryDelay = time.Second
+
+//messages received in one round of a session
+type roundInfo struct{
+	//set of SENDER_IDs which sent a BVAL for 0 / 1
+	bvals		[2]map[string]struct{}
+	bvalSent	[2]bool
+	//values with 2t+1 BVALs, bin_values in the article
+	binValues	[2]bool
+	//map [ SENDER_ID -> VALUE ]
+	//value of the first AUX of every sender
+	auxs		map[string]bool
+	auxSent	bool
+	coin		*bool //nil until the coin of the round is tossed
+	coinPending	bool
+}
+
+//struct to keep info on an agreement session
+type sessionInfo struct{
+	//a node takes part in the session only once Agree gives it its input,
+	//messages received before that are kept and counted when it starts
+	started	bool
+	//membership epoch the session started in on this node, n, t and the peers are pinned to it
+	epoch		uint64
+	n, t		int
+	peers		map[string]struct{} //peers of the epoch, only their messages count
+	round		uint32 //round this node is in
+	est		bool //estimate of this node for the current round
+	decided	bool
+	decision	bool
+	decidedIn	uint32
+	//set of SENDER_IDs which sent a TERM for 0 / 1
+	terms		[2]map[string]struct{}
+	termSent	bool
+	//halted sessions ignore every message, see handleTerm
+	halted	bool
+	lastActivity	time.Time
+	decidedAt		time.Time
+
+	//map [ ROUND -> roundInfo ]
+	rounds	map[uint32]*roundInfo
+}
+
+//Omni is the part of omni.Manager aba relies on
+type Omni interface{
+	ID() peer.ID
+	OmniPublisher(messages.Message) error
+	Register(messages.Codec)
+}
+
+//Membership is the part of membership.Manager aba relies on
+type Membership interface{
+	Current() membership.Epoch
+}
+
+//events handled by the event loop, see eventLoop
+type agreeEvent struct{
+	sessionID	string
+	bit			bool
+	decisionC	chan<- *messages.AbaDecision //decision is sent back if the session already decided
+}
+type coinEvent struct{
+	sessionID	string
+	round			uint32
+	value			bool
+	err			error
+	retry			bool //set when the coin is to be tossed again after it failed
+}
+
+//Manager runs the binary byzantine agreement of mostefaoui, moumen & raynal
+//(signature-free asynchronous binary byzantine consensus with t < n/3, O(n^2) messages,
+//and O(1) expected time). In every round:
+//	- BV-broadcast the estimate: send BVAL(est), send BVAL(b) upon t+1 BVAL(b),
+//	  add b to bin_values upon 2t+1 BVAL(b)
+//	- once bin_values is not empty, send AUX(w) for some w in bin_values
+//	- wait for n-t AUX carrying values of bin_values, vals are the values they carry
+//	- toss the coin s. If vals = {v}, the estimate becomes v and v is decided if v = s,
+//	  otherwise the estimate becomes s
+//a node which decided sends TERM(v). t+1 TERM(v) let a node decide v and 2t+1 TERM(v) let it
+//stop the session, as n-t correct nodes will get the same TERMs.
+type Manager struct{
+	logger	*zap.Logger
+	cfg		rbc0.Config
+	nodeID	string
+	coin		Coin
+	omniManager Omni
+	membershipManager Membership
+
+	msgC		chan messages.MsgAba //aba messages received from the omni network
+	agreeC	chan agreeEvent
+	coinC		chan coinEvent
+
+	//map [ SESSION_ID -> sessionInfo ]
+	sessionInfoMap	map[string]*sessionInfo
+	//SESSION_IDs of the decided sessions still in sessionInfoMap, in the order they were decided
+	decidedOrder	[]string
+
+	//this Manager sends AbaDecisions via msgPublisher to the subscribers
+	msgPublisher	messages.Publisher
+	subscribers		messages.Fanout
+}
+
+//coin must be a threshold coin, like coin.Manager, outside of tests
+func NewManager(logger *zap.Logger, cfg rbc0.Config, coin Coin, membershipManager Membership, omniManager Omni) (*Manager, error){
+	if logger == nil{
+		logger = zap.NewNop()
+	}
+	cfg = cfg.WithDefaults()
+	if coin == nil{
+		return nil, errors.New("aba can't run without a coin")
+	}
+
+	pub, sub := messages.NewSubscription()
+
+	m := &Manager{
+		logger:			logger,
+		cfg:				cfg,
+		nodeID:			omniManager.ID().String(),
+		coin:				coin,
+		omniManager:	omniManager,
+		membershipManager:	membershipManager,
+		msgC:				make(chan messages.MsgAba),
+		agreeC:			make(chan agreeEvent),
+		coinC:			make(chan coinEvent),
+		sessionInfoMap:	make(map[string]*sessionInfo),
+		decidedOrder:	make([]string, 0),
+		msgPublisher:	pub,
+	}
+
+	go m.subscribers.Run(sub, func(dropped uint64){ metrics.Add("messages_dropped", int64(dropped)) })
+	go m.eventLoop()
+	m.omniManager.Register(messages.Codec{
+		Type:				genmsg.Message_ABA,
+		Marshal:			messages.MarshalAba,
+		Unmarshal:		messages.UnmarshalAba,
+		Handler:			m.handleOmniMsg,
+	})
+	return m, nil
+}
+
+//the only goroutine which reads or changes protocol state
+func (m *Manager) eventLoop(){
+	gcTicker := time.NewTicker(m.cfg.GCInterval)
+	defer gcTicker.Stop()
+
+	for{
+		select{
+			case msg := <-m.msgC:
+				m.handleMsg(msg)
+			case ev := <-m.agreeC:
+				ev.decisionC <- m.start(ev.sessionID, ev.bit)
+			case ev := <-m.coinC:
+				m.handleCoin(ev)
+			case now := <-gcTicker.C:
+				m.gc(now)
+		}
+	}
+}
+
+//Handler of the codec registered with omni, pass on the received messages to the event loop
+func (m *Manager) handleOmniMsg(in messages.Message){
+	msg, ok := messages.Unwrap(in).(messages.MsgAba)
+	if !ok{
+		return
+	}
+	m.msgC <- msg
+}
+
+func bit(value bool) int{
+	if value{
+		return 1
+	}
+	return 0
+}
+
+func (m *Manager) session(sessionID string) *sessionInfo{
+	si, exists := m.sessionInfoMap[sessionID]
+	if exists{
+		return si
+	}
+
+	epoch := m.membershipManager.Current()
+	si = &sessionInfo{
+		epoch:	epoch.Number,
+		n:			epoch.N,
+		t:			rbc0.MaxFaulty(epoch.N),
+		peers:	make(map[string]struct{}, len(epoch.Peers)),
+		terms:	[2]map[string]struct{}{make(map[string]struct{}), make(map[string]struct{})},
+		lastActivity:	time.Now(),
+		rounds:	make(map[uint32]*roundInfo),
+	}
+	for _, p := range epoch.Peers{
+		si.peers[p.String()] = struct{}{}
+	}
+	m.sessionInfoMap[sessionID] = si
+	m.updateMetrics()
+
+	m.logger.Debug("new aba session",
+		zap.String("sessionID", sessionID),
+		zap.Uint64("epoch", si.epoch),
+		zap.Int("n", si.n),
+		zap.Int("t", si.t),
+	)
+	return si
+}
+
+func isPeer(epoch membership.Epoch, nodeID string) bool{
+	for _, p := range epoch.Peers{
+		if p.String() == nodeID{
+			return true
+		}
+	}
+	return false
+}
+
+func (si *sessionInfo) roundInfo(round uint32) *roundInfo{
+	ri, exists := si.rounds[round]
+	if !exists{
+		ri = &roundInfo{
+			bvals:	[2]map[string]struct{}{make(map[string]struct{}), make(map[string]struct{})},
+			auxs:		make(map[string]bool),
+		}
+		si.rounds[round] = ri
+	}
+	return ri
+}
+
+func (m *Manager) handleMsg(msg messages.MsgAba){
+	si, exists := m.sessionInfoMap[msg.SessionID]
+	if !exists{
+		//only peers make this node keep a session, else anyone could fill sessionInfoMap
+		if !isPeer(m.membershipManager.Current(), msg.SenderID){
+			m.logger.Warn("aba discarding msg of a node which is no peer of the current epoch",
+				zap.String("sessionID", msg.SessionID),
+				zap.String("senderID", msg.SenderID),
+			)
+			return
+		}
+		si = m.session(msg.SessionID)
+	}
+	if si.halted{
+		return
+	}
+	if _, isPeer := si.peers[msg.SenderID]; !isPeer{
+		m.logger.Warn("aba discarding msg of a node which is no peer of the session's epoch",
+			zap.String("sessionID", msg.SessionID),
+			zap.String("senderID", msg.SenderID),
+		)
+		return
+	}
+	if (msg.Type == typeBval || msg.Type == typeAux) && msg.Round >= si.round + roundWindow{
+		return
+	}
+	si.lastActivity = time.Now()
+
+	switch msg.Type{
+		case typeBval:
+			if msg.Round < si.round{ //rounds this node left are over
+				return
+			}
+			si.roundInfo(msg.Round).bvals[bit(msg.Value)][msg.SenderID] = struct{}{}
+		case typeAux:
+			if msg.Round < si.round{
+				return
+			}
+			ri := si.roundInfo(msg.Round)
+			if _, exists := ri.auxs[msg.SenderID]; !exists{ //only the first AUX of a sender counts
+				ri.auxs[msg.SenderID] = msg.Value
+			}
+		case typeTerm:
+			m.handleTerm(msg.SessionID, msg.SenderID, msg.Value)
+			return
+		default:
+			m.logger.Warn("aba discarding msg of unknown type", zap.Uint32("type", msg.Type))
+			return
+	}
+
+	m.progress(msg.SessionID)
+}
+
+//start the session with this node's input, unless it already decided
+func (m *Manager) start(sessionID string, value bool) *messages.AbaDecision{
+	si := m.session(sessionID)
+	if si.decided{
+		return &messages.AbaDecision{SessionID: sessionID, Value: si.decision, Round: si.decidedIn}
+	}
+	if !si.started{
+		si.started = true
+		si.est = value
+		si.lastActivity = time.Now()
+		m.progress(sessionID)
+	}
+	return nil
+}
+
+//move the session as far forward as the messages received so far allow
+func (m *Manager) progress(sessionID string){
+	si := m.sessionInfoMap[sessionID]
+	n, t := si.n, si.t
+
+	for si.started && !si.halted{
+		ri := si.roundInfo(si.round)
+
+		//BV-broadcast
+		if !ri.bvalSent[bit(si.est)]{
+			m.send(sessionID, typeBval, si.est)
+		}
+		for _, b := range []bool{false, true}{
+			if !ri.bvalSent[bit(b)] && len(ri.bvals[bit(b)]) >= t+1{
+				m.send(sessionID, typeBval, b)
+			}
+			if len(ri.bvals[bit(b)]) >= 2*t+1{
+				ri.binValues[bit(b)] = true
+			}
+		}
+
+		if !ri.auxSent{
+			if ri.binValues[bit(si.est)]{
+				m.send(sessionID, typeAux, si.est)
+			} else if ri.binValues[bit(!si.est)]{
+				m.send(sessionID, typeAux, !si.est)
+			} else{
+				return
+			}
+		}
+
+		//wait for n-t AUX carrying values of bin_values
+		var vals [2]bool
+		count := 0
+		for _, value := range ri.auxs{
+			if ri.binValues[bit(value)]{
+				vals[bit(value)] = true
+				count++
+			}
+		}
+		if count < n-t{
+			return
+		}
+
+		if ri.coin == nil{
+			m.tossCoin(sessionID, si.round)
+			return
+		}
+		coin := *ri.coin
+
+		if vals[0] != vals[1]{ //vals holds a single value v
+			v := vals[1]
+			si.est = v
+			if v == coin && !si.decided{
+				m.decide(sessionID, v)
+			}
+		} else{
+			si.est = coin
+		}
+
+		delete(si.rounds, si.round)
+		si.round++
+	}
+}
+
+//toss the coin of the round outside of the event loop, the result comes back as a coinEvent
+func (m *Manager) tossCoin(sessionID string, round uint32){
+	ri := m.sessionInfoMap[sessionID].roundInfo(round)
+	if ri.coinPending{
+		return
+	}
+	ri.coinPending = true
+
+	go func(){
+		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.StalledTimeout)
+		defer cancel()
+		value, err := m.coin.Toss(ctx, sessionID, round)
+		m.coinC <- coinEvent{sessionID: sessionID, round: round, value: value, err: err}
+	}()
+}
+
+func (m *Manager) handleCoin(ev coinEvent){
+	si, exists := m.sessionInfoMap[ev.sessionID]
+	if !exists || si.halted || ev.round != si.round{
+		return
+	}
+	ri := si.roundInfo(ev.round)
+	if ev.retry{
+		ri.coinPending = false
+		m.tossCoin(ev.sessionID, ev.round)
+		return
+	}
+	if ev.err != nil{
+		//the coin stays pending until it is tossed again
+		m.logger.Error("failed tossing aba coin, retrying",
+			zap.String("sessionID", ev.sessionID),
+			zap.Uint32("round", ev.round),
+			zap.Error(ev.err),
+		)
+		time.AfterFunc(coinRetryDelay, func(){ m.coinC <- coinEvent{sessionID: ev.sessionID, round: ev.round, retry: true} })
+		return
+	}
+	ri.coinPending = false
+	value := ev.value
+	ri.coin = &value
+	m.progress(ev.sessionID)
+}
+
+//t+1 TERM(v) show a correct node decided v, 2t+1 TERM(v) show t+1 correct nodes did,
+//whose TERMs will make every correct node decide v and send TERM(v) as well
+func (m *Manager) handleTerm(sessionID, senderID string, value bool){
+	si := m.sessionInfoMap[sessionID]
+	si.terms[bit(value)][senderID] = struct{}{}
+
+	terms := len(si.terms[bit(value)])
+	if terms >= si.t+1 && !si.decided{
+		m.decide(sessionID, value)
+	}
+	if terms >= 2*si.t+1 && si.decided{
+		si.halted = true
+		si.rounds = nil
+		m.logger.Debug("aba session halted", zap.String("sessionID", sessionID))
+	}
+}
+
+func (m *Manager) decide(sessionID string, value bool){
+	si := m.sessionInfoMap[sessionID]
+	si.decided = true
+	si.decision = value
+	si.decidedIn = si.round
+	si.decidedAt = time.Now()
+	m.decidedOrder = append(m.decidedOrder, sessionID)
+	m.updateMetrics()
+	m.logger.Info("aba session DECIDED",
+		zap.String("sessionID", sessionID),
+		zap.Bool("value", value),
+		zap.Uint32("round", si.round),
+	)
+
+	msg := messages.AbaDecision{SessionID: sessionID, Value: value, Round: si.round}
+	if err := m.msgPublisher.Publish(msg); err != nil{
+		m.logger.Error("failed passing aba message to the subscribers")
+	}
+
+	if !si.termSent{
+		si.termSent = true
+		m.send(sessionID, typeTerm, value)
+	}
+}
+
+//broadcast a message of the current round and count it as this node's
+func (m *Manager) send(sessionID string, msgType uint32, value bool){
+	si := m.sessionInfoMap[sessionID]
+	msg := messages.MsgAba{
+		SessionID:	sessionID,
+		Type:			msgType,
+		Round:		si.round,
+		Value:		value,
+	}
+	if err := m.omniManager.OmniPublisher(&msg); err != nil{
+		m.logger.Error("sending aba msg FAILED", zap.String("sessionID", sessionID))
+	}
+
+	switch msgType{
+		case typeBval:
+			ri := si.roundInfo(si.round)
+			ri.bvalSent[bit(value)] = true
+			ri.bvals[bit(value)][m.nodeID] = struct{}{}
+		case typeAux:
+			ri := si.roundInfo(si.round)
+			ri.auxSent = true
+			ri.auxs[m.nodeID] = value
+		case typeTerm:
+			m.handleTerm(sessionID, m.nodeID, value)
+	}
+}
+
+
+//other parts of the node can call this to receive the AbaDecisions of this node
+func (m *Manager) SubscribeToMessages() messages.Subscriber{
+	return m.subscribers.Subscribe(messages.DefaultSubscriptionConfig())
+}
+
+//Agree gives this node's input to the session sessionID and returns the decision.
+//every node of the agreement must call Agree with the same sessionID.
+//if ctx ends first, ctx.Err() is returned and the session keeps going without the caller.
+func (m *Manager) Agree(ctx context.Context, sessionID string, value bool) (messages.AbaDecision, error){
+	//subscribe before the session starts, so its decision can't be missed
+	sub := m.SubscribeToMessages()
+	defer sub.Close()
+
+	decisionC := make(chan *messages.AbaDecision, 1)
+	m.agreeC <- agreeEvent{sessionID: sessionID, bit: value, decisionC: decisionC}
+	if decision := <-decisionC; decision != nil{
+		return *decision, nil
+	}
+
+	msg, err := messages.WaitFor(ctx, sub, func(msg messages.Message) bool{
+		decision, ok := msg.(messages.AbaDecision)
+		return ok && decision.SessionID == sessionID
+	})
+	switch{
+		case err == nil:
+			return msg.(messages.AbaDecision), nil
+		case err == ctx.Err():
+			m.logger.Debug("aba session not decided before deadline", zap.String("sessionID", sessionID))
+		default:
+			m.logger.Error("failed receiving decision of AGREE", zap.Error(err))
+	}
+	return messages.AbaDecision{}, err
+}
@@ -0,0 +1,176 @@
+package aba
+
+import(
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"go.uber.org/zap"
+
+	"distry/internal/testnet"
+	"distry/membership"
+	"distry/messages"
+	"distry/rbc0"
+)
+
+//create aba Managers for the first running of n nodes, the rest never answer
+func setupManagers(t *testing.T, n, running int) ([]*Manager, *testnet.Network){
+	t.Helper()
+	return setupManagersWithCoin(t, n, running, func() Coin{ return HashCoin{} })
+}
+
+//like setupManagers, every Manager tosses the coin newCoin returns
+func setupManagersWithCoin(t *testing.T, n, running int, newCoin func() Coin) ([]*Manager, *testnet.Network){
+	t.Helper()
+
+	network := &testnet.Network{}
+	epoch := membership.Epoch{Number: 1, N: n}
+	for i := 0; i < n; i++{
+		epoch.Peers = append(epoch.Peers, peer.ID(fmt.Sprintf("node%d", i)))
+	}
+
+	managers := make([]*Manager, running)
+	for i := range managers{
+		node := network.AddNode(epoch.Peers[i])
+		m, err := NewManager(nil, rbc0.DefaultConfig(), newCoin(), testnet.Membership{Epoch: epoch}, node)
+		if err != nil{
+			t.Fatal(err)
+		}
+		managers[i] = m
+	}
+	return managers, network
+}
+
+//run sessionsNum sessions on all managers at once, inputs[i] is the input of manager i.
+//check that all managers decide the same bit in every session, return the decisions
+func agree(t *testing.T, managers []*Manager, sessionsNum int, inputs []bool) []bool{
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	decisions := make([][]messages.AbaDecision, len(managers))
+	errs := make(chan error, len(managers)*sessionsNum)
+	wg := new(sync.WaitGroup)
+	for i, m := range managers{
+		decisions[i] = make([]messages.AbaDecision, sessionsNum)
+		for s := 0; s < sessionsNum; s++{
+			wg.Add(1)
+			go func(i, s int, m *Manager){
+				defer wg.Done()
+				decision, err := m.Agree(ctx, fmt.Sprintf("%s_%v_%d", t.Name(), inputs, s), inputs[i])
+				if err != nil{
+					errs <- err
+					return
+				}
+				decisions[i][s] = decision
+			}(i, s, m)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs{
+		t.Fatal(err)
+	}
+
+	decided := make([]bool, sessionsNum)
+	for s := 0; s < sessionsNum; s++{
+		decided[s] = decisions[0][s].Value
+		for i := range managers{
+			if decisions[i][s].Value != decided[s]{
+				t.Fatalf("session %d: node %d decided %v, node 0 decided %v", s, i, decisions[i][s].Value, decided[s])
+			}
+		}
+	}
+	return decided
+}
+
+func TestValidity(t *testing.T){
+	managers, _ := setupManagers(t, 4, 4)
+	for _, input := range []bool{false, true}{
+		inputs := []bool{input, input, input, input}
+		for s, decided := range agree(t, managers, 5, inputs){
+			if decided != input{
+				t.Fatalf("session %d decided %v, every node proposed %v", s, decided, input)
+			}
+		}
+	}
+}
+
+func TestAgreementMixedInputs(t *testing.T){
+	managers, _ := setupManagers(t, 4, 4)
+	agree(t, managers, 20, []bool{false, true, false, true})
+}
+
+func TestAgreementSilentNode(t *testing.T){
+	//t=1 of the n=4 nodes never answers
+	managers, _ := setupManagers(t, 4, 3)
+	agree(t, managers, 20, []bool{true, false, true})
+}
+
+//failingCoin fails the first toss of every round a while after it is asked, then tosses the HashCoin
+type failingCoin struct{
+	lock		sync.Mutex
+	tossed	map[string]bool
+}
+
+func (c *failingCoin) Toss(ctx context.Context, sessionID string, round uint32) (bool, error){
+	c.lock.Lock()
+	key := fmt.Sprintf("%s_%d", sessionID, round)
+	tossed := c.tossed[key]
+	c.tossed[key] = true
+	c.lock.Unlock()
+
+	if !tossed{
+		time.Sleep(100*time.Millisecond)
+		return false, errors.New("coin not ready")
+	}
+	return HashCoin{}.Toss(ctx, sessionID, round)
+}
+
+func TestCoinRetry(t *testing.T){
+	//every message of the round arrives before its coin fails, only the retry tosses it again
+	managers, _ := setupManagersWithCoin(t, 4, 4, func() Coin{ return &failingCoin{tossed: make(map[string]bool)} })
+	agree(t, managers, 5, []bool{false, false, false, false})
+}
+
+func TestMessagesOfNonPeers(t *testing.T){
+	managers, network := setupManagers(t, 4, 4)
+	inputs := []bool{false, false, false, false}
+	sessionID := fmt.Sprintf("%s_%v_%d", t.Name(), inputs, 0)
+
+	//t+1 TERM(true) of peers would make the nodes decide true, though all of them input false
+	for _, id := range []peer.ID{"outsider0", "outsider1"}{
+		outsider := network.AddNode(id)
+		for _, msgType := range []uint32{typeBval, typeAux, typeTerm}{
+			msg := messages.MsgAba{SessionID: sessionID, Type: msgType, Value: true}
+			if err := outsider.OmniPublisher(&msg); err != nil{
+				t.Fatal(err)
+			}
+		}
+	}
+	time.Sleep(50*time.Millisecond) //let them arrive before the nodes start
+
+	if decided := agree(t, managers, 1, inputs); decided[0]{
+		t.Fatal("messages of nodes outside of the epoch made the nodes decide true")
+	}
+}
+
+func TestSessionsOfNonPeers(t *testing.T){
+	//no event loop runs, handleMsg is called directly
+	epoch := membership.Epoch{Number: 1, N: 4, Peers: []peer.ID{"node0", "node1", "node2", "node3"}}
+	m := &Manager{
+		logger:			zap.NewNop(),
+		membershipManager:	testnet.Membership{Epoch: epoch},
+		sessionInfoMap:	make(map[string]*sessionInfo),
+	}
+	for s := 0; s < 100; s++{
+		m.handleMsg(messages.MsgAba{SessionID: fmt.Sprintf("session%d", s), SenderID: "outsider", Type: typeTerm, Value: true})
+	}
+	if len(m.sessionInfoMap) != 0{
+		t.Fatalf("messages of a node outside of the epoch created %d sessions", len(m.sessionInfoMap))
+	}
+}
@@ -0,0 +1,31 @@
+package aba
+
+import(
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+//Coin is the common coin of the agreement.
+//every correct node tossing the coin of the same session and round must get the same value,
+//and the value must not be known to the adversary before t+1 correct nodes toss it.
+//Toss may block (a threshold coin exchanges shares with other nodes), it is never called
+//from the event loop.
+type Coin interface{
+	Toss(ctx context.Context, sessionID string, round uint32) (bool, error)
+}
+
+//HashCoin derives the coin from the session and round alone.
+//every node gets the same value, but so does the adversary, long before the round.
+//An adversary controlling the network scheduling can use it to keep the agreement from
+//terminating, so it is only fit for tests.
+type HashCoin struct{}
+
+func (HashCoin) Toss(_ context.Context, sessionID string, round uint32) (bool, error){
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], round)
+	h := sha256.New()
+	h.Write([]byte(sessionID))
+	h.Write(buf[:])
+	return h.Sum(nil)[0] & 1 == 1, nil
+}
@@ -0,0 +1,48 @@
+package aba
+
+import(
+	"time"
+
+	"go.uber.org/zap"
+
+	"distry/internal/retention"
+)
+
+//current session counts of the node, served by expvar under /debug/vars
+var metrics = retention.NewMetrics("aba")
+
+//evict decided sessions which are too old or too many and sessions which stalled, like rbc0 does.
+//session IDs are chosen by the callers, so there are no low-water marks: a late message
+//of an evicted session starts it anew, and the session is evicted again once it stalls.
+func (m *Manager) gc(now time.Time){
+	evictedDecided := retention.Expired(now, m.cfg.AcceptedMaxAge, m.cfg.AcceptedMaxCount, len(m.decidedOrder), func(i int) time.Time{
+		return m.sessionInfoMap[m.decidedOrder[i]].decidedAt
+	})
+	for _, sessionID := range m.decidedOrder[:evictedDecided]{
+		delete(m.sessionInfoMap, sessionID)
+	}
+	m.decidedOrder = m.decidedOrder[evictedDecided:]
+
+	evictedStalled := 0
+	for sessionID, si := range m.sessionInfoMap{
+		if !si.decided && retention.Stalled(now, si.lastActivity, m.cfg.StalledTimeout){
+			m.logger.Info("evicting stalled aba session",
+				zap.String("sessionID", sessionID),
+				zap.Uint32("round", si.round),
+			)
+			delete(m.sessionInfoMap, sessionID)
+			evictedStalled++
+		}
+	}
+
+	metrics.Add("sessions_evicted_decided", int64(evictedDecided))
+	metrics.Add("sessions_evicted_stalled", int64(evictedStalled))
+	m.updateMetrics()
+}
+
+//publish the current session counts
+func (m *Manager) updateMetrics(){
+	decided := len(m.decidedOrder)
+	metrics.SetGauge("sessions_active", len(m.sessionInfoMap) - decided)
+	metrics.SetGauge("sessions_decided", decided)
+}
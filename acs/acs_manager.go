@@ -0,0 +1,450 @@
+package acs
+
+import(
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"distry/internal/retention"
+	"distry/membership"
+	"distry/messages"
+	"distry/rbc0"
+)
+
+//rbc0 stream the proposals are broadcast in, the counter of their round is the acs epoch
+const rbc0Stream = "acs"
+
+//a proposal is broadcast as the view of its proposer followed by its payload
+const viewLen = 2*8
+
+//Rbc is the part of rbc0.Manager acs relies on
+type Rbc interface{
+	BroadcastInstance(ctx context.Context, stream string, counter uint64, payload string) (messages.Rbc0Delivery, error)
+	SubscribeToMessages() messages.Subscriber
+}
+
+//Agreement is the part of aba.Manager acs relies on
+type Agreement interface{
+	Agree(ctx context.Context, sessionID string, value bool) (messages.AbaDecision, error)
+}
+
+//Membership is the part of membership.Manager acs relies on
+type Membership interface{
+	Current() membership.Epoch
+}
+
+//struct to keep info on an acs epoch
+type epochInfo struct{
+	//proposers of the epoch: the peers of the membership epoch current when it started on this node.
+	//the proposers are pinned by view, a digest of them, see View
+	proposers	map[string]struct{}
+	view		string
+	n, t		int
+	proposed	bool //this node proposed in the epoch
+
+	//map [ PROPOSER_ID -> PAYLOAD ]
+	//proposals delivered by rbc0
+	proposals	map[string]string
+	//set of PROPOSER_IDs whose agreement was given this node's input
+	inputs		map[string]struct{}
+	//map [ PROPOSER_ID -> DECISION ]
+	//decisions of the agreements, true includes the proposal in the output
+	decisions	map[string]bool
+	ones			int //number of agreements which decided true
+
+	output		*messages.AcsOutput //nil until the epoch is over
+	lastActivity	time.Time
+	outputAt			time.Time
+}
+
+//events handled by the event loop, see eventLoop
+type proposeEvent struct{
+	epoch			uint64
+	payload		string
+	resultC		chan<- proposeResult
+}
+type proposeResult struct{
+	output	*messages.AcsOutput //set if the epoch is already over
+	err		error
+}
+type decisionEvent struct{
+	epoch			uint64
+	proposer		string
+	value			bool
+	err			error //set if the agreement is not decided, value is then the input to retry with
+}
+
+//Manager runs the asynchronous common subset of honeybadger BFT.
+//in every epoch, every node reliably broadcasts its proposal with rbc0 and every proposer
+//gets a binary agreement deciding whether its proposal makes it into the output:
+//	- upon delivery of the proposal of j, input true to the agreement of j
+//	- upon n-t agreements deciding true, input false to every agreement not given input yet
+//	- once every agreement decided, output the proposals of those which decided true
+//every correct node outputs the same set of at least n-t proposals.
+//nodes only count the proposals of their view, so every epoch is decided by nodes with the same proposers.
+type Manager struct{
+	logger	*zap.Logger
+	cfg		rbc0.Config
+	rbc		Rbc
+	agreement	Agreement
+	membershipManager	Membership
+
+	deliveryC	chan messages.Rbc0Delivery //proposals delivered by rbc0
+	proposeC		chan proposeEvent
+	decisionC	chan decisionEvent
+
+	//map [ EPOCH -> epochInfo ]
+	epochInfoMap	map[uint64]*epochInfo
+	//epochs which are over and still in epochInfoMap, in the order they ended
+	outputOrder		[]uint64
+	//evicted epochs, their proposals are dropped
+	lowWater			*retention.LowWater
+
+	//this Manager sends AcsOutputs via msgPublisher to the subscribers
+	msgPublisher	messages.Publisher
+	subscribers		messages.Fanout
+}
+
+func NewManager(logger *zap.Logger, cfg rbc0.Config, membershipManager Membership, rbc Rbc, agreement Agreement) *Manager{
+	if logger == nil{
+		logger = zap.NewNop()
+	}
+	cfg = cfg.WithDefaults()
+
+	pub, sub := messages.NewSubscription()
+
+	m := &Manager{
+		logger:			logger,
+		cfg:				cfg,
+		rbc:				rbc,
+		agreement:		agreement,
+		membershipManager:	membershipManager,
+		deliveryC:		make(chan messages.Rbc0Delivery),
+		proposeC:		make(chan proposeEvent),
+		decisionC:		make(chan decisionEvent),
+		epochInfoMap:	make(map[uint64]*epochInfo),
+		outputOrder:	make([]uint64, 0),
+		lowWater:		retention.NewLowWater(cfg.EvictedWindow),
+		msgPublisher:	pub,
+	}
+
+	go m.subscribers.Run(sub, func(dropped uint64){ metrics.Add("messages_dropped", int64(dropped)) })
+	go m.eventLoop()
+	go m.rbcReceiver()
+	return m
+}
+
+//the only goroutine which reads or changes protocol state
+func (m *Manager) eventLoop(){
+	gcTicker := time.NewTicker(m.cfg.GCInterval)
+	defer gcTicker.Stop()
+
+	for{
+		select{
+			case delivery := <-m.deliveryC:
+				m.handleDelivery(delivery)
+			case ev := <-m.proposeC:
+				ev.resultC <- m.propose(ev.epoch, ev.payload)
+			case ev := <-m.decisionC:
+				m.handleDecision(ev)
+			case now := <-gcTicker.C:
+				m.gc(now)
+		}
+	}
+}
+
+//pass on the rbc0 deliveries of the acs stream
+func (m *Manager) rbcReceiver(){
+	sub := m.rbc.SubscribeToMessages()
+
+	for{
+		msg, err := sub.Next()
+		if err != nil{
+			m.logger.Error("failed receiving msg from rbc0", zap.Error(err))
+			continue
+		}
+
+		delivery, ok := msg.(messages.Rbc0Delivery)
+		if !ok{
+			continue
+		}
+		if _, stream, _, ok := rbc0.ParseProtocolID(delivery.ProtocolID); !ok || stream != rbc0Stream{
+			continue
+		}
+		m.deliveryC <- delivery
+	}
+}
+
+//return the info on epoch, create it if this is its first event.
+//nil is returned for epochs which were evicted
+func (m *Manager) epoch(epoch uint64) *epochInfo{
+	ei, exists := m.epochInfoMap[epoch]
+	if exists{
+		return ei
+	}
+	if m.lowWater.IsLate("", epoch){
+		return nil
+	}
+
+	membershipEpoch := m.membershipManager.Current()
+	ei = &epochInfo{
+		proposers:	make(map[string]struct{}),
+		n:				membershipEpoch.N,
+		t:				rbc0.MaxFaulty(membershipEpoch.N),
+		proposals:	make(map[string]string),
+		inputs:		make(map[string]struct{}),
+		decisions:	make(map[string]bool),
+		lastActivity:	time.Now(),
+	}
+	for _, p := range membershipEpoch.Peers{
+		ei.proposers[p.String()] = struct{}{}
+	}
+	ei.view = View(membershipEpoch)
+	m.epochInfoMap[epoch] = ei
+	m.updateMetrics()
+
+	m.logger.Debug("new acs epoch",
+		zap.Uint64("epoch", epoch),
+		zap.Int("n", ei.n),
+		zap.Int("t", ei.t),
+		zap.String("view", ei.view),
+	)
+	return ei
+}
+
+//View returns the digest of the peers of epoch, the proposers of the acs epochs which start in it.
+//nodes agree on the proposals of an acs epoch only with the nodes of the same view
+func View(epoch membership.Epoch) string{
+	h := sha256.New()
+	for _, p := range epoch.Peers{ //sorted
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:viewLen]
+}
+
+func isPeer(epoch membership.Epoch, nodeID string) bool{
+	for _, p := range epoch.Peers{
+		if p.String() == nodeID{
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Manager) handleDelivery(delivery messages.Rbc0Delivery){
+	proposer, _, epoch, _ := rbc0.ParseProtocolID(delivery.ProtocolID)
+	if _, exists := m.epochInfoMap[epoch]; !exists && !isPeer(m.membershipManager.Current(), proposer){
+		//don't let nodes outside of the network start epochs
+		m.logger.Warn("acs discarding proposal of a node which is no peer",
+			zap.Uint64("epoch", epoch),
+			zap.String("proposerID", proposer),
+		)
+		return
+	}
+	ei := m.epoch(epoch)
+	if ei == nil || ei.output != nil{
+		return
+	}
+	if _, exists := ei.proposers[proposer]; !exists{
+		m.logger.Warn("acs discarding proposal of a node which is no proposer of the epoch",
+			zap.Uint64("epoch", epoch),
+			zap.String("proposerID", proposer),
+		)
+		return
+	}
+	if len(delivery.Payload) < viewLen || delivery.Payload[:viewLen] != ei.view{
+		//the proposer doesn't see the same peers, its proposal is left out like a missing one
+		m.logger.Warn("acs discarding proposal of another view",
+			zap.Uint64("epoch", epoch),
+			zap.String("proposerID", proposer),
+			zap.String("view", ei.view),
+		)
+		return
+	}
+	ei.lastActivity = time.Now()
+
+	//rbc0 accepts a round only once, so there is a single proposal per proposer and epoch
+	ei.proposals[proposer] = delivery.Payload[viewLen:]
+	m.input(epoch, proposer, true)
+	m.checkOutput(epoch)
+}
+
+//start the agreement on the proposal of proposer with this node's input, unless it has one
+func (m *Manager) input(epoch uint64, proposer string, value bool){
+	ei := m.epochInfoMap[epoch]
+	if _, exists := ei.inputs[proposer]; exists{
+		return
+	}
+	ei.inputs[proposer] = struct{}{}
+	m.agree(epoch, ei.view, proposer, value)
+}
+
+//Agree blocks until the agreement decides, the decision (or the failure) comes back as a decisionEvent
+func (m *Manager) agree(epoch uint64, view, proposer string, value bool){
+	go func(){
+		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.StalledTimeout)
+		defer cancel()
+		decision, err := m.agreement.Agree(ctx, SessionID(epoch, view, proposer), value)
+		ev := decisionEvent{epoch: epoch, proposer: proposer, value: decision.Value, err: err}
+		if err != nil{
+			ev.value = value
+		}
+		m.decisionC <- ev
+	}()
+}
+
+//SessionID returns the ID of the agreement on the proposal of proposer in epoch, among the nodes of view
+func SessionID(epoch uint64, view, proposer string) string{
+	return "acs_" + strconv.FormatUint(epoch, 10) + "_" + view + "_" + proposer
+}
+
+func (m *Manager) handleDecision(ev decisionEvent){
+	ei, exists := m.epochInfoMap[ev.epoch]
+	if !exists || ei.output != nil{
+		return
+	}
+	if _, exists := ei.decisions[ev.proposer]; exists{
+		return
+	}
+	if ev.err != nil{
+		//an epoch without the decision never ends, keep waiting for it until the epoch stalls.
+		//retrying doesn't count as activity, so the gc still evicts an epoch whose agreements are stuck
+		m.logger.Warn("acs agreement not decided, retrying",
+			zap.Uint64("epoch", ev.epoch),
+			zap.String("proposerID", ev.proposer),
+			zap.Error(ev.err),
+		)
+		metrics.Add("agreements_retried", 1)
+		m.agree(ev.epoch, ei.view, ev.proposer, ev.value)
+		return
+	}
+	ei.lastActivity = time.Now()
+	ei.decisions[ev.proposer] = ev.value
+
+	if ev.value{
+		ei.ones++
+		if ei.ones == ei.n - ei.t{
+			//enough proposals are in, leave out the ones not delivered yet
+			for proposer := range ei.proposers{
+				m.input(ev.epoch, proposer, false)
+			}
+		}
+	}
+	m.checkOutput(ev.epoch)
+}
+
+//once every agreement decided and every proposal decided in was delivered, output them.
+//an agreement decides true only if some correct node input true, so the proposal was
+//delivered to that node and rbc0 will deliver it to every correct node.
+func (m *Manager) checkOutput(epoch uint64){
+	ei := m.epochInfoMap[epoch]
+	if len(ei.decisions) < len(ei.proposers){
+		return
+	}
+
+	proposals := make([]messages.AcsProposal, 0, ei.ones)
+	for proposer, value := range ei.decisions{
+		if !value{
+			continue
+		}
+		payload, delivered := ei.proposals[proposer]
+		if !delivered{
+			return
+		}
+		proposals = append(proposals, messages.AcsProposal{ProposerID: proposer, Payload: payload})
+	}
+	sort.Slice(proposals, func(i, j int) bool{ return proposals[i].ProposerID < proposals[j].ProposerID })
+
+	ei.output = &messages.AcsOutput{Epoch: epoch, Proposals: proposals}
+	ei.outputAt = time.Now()
+	m.outputOrder = append(m.outputOrder, epoch)
+	m.updateMetrics()
+	m.logger.Info("acs epoch OUTPUT",
+		zap.Uint64("epoch", epoch),
+		zap.Int("proposals", len(proposals)),
+	)
+
+	if err := m.msgPublisher.Publish(*ei.output); err != nil{
+		m.logger.Error("failed passing acs output to the subscribers")
+	}
+	//cleanup epoch resources
+	ei.proposals = nil
+	ei.inputs = nil
+}
+
+//broadcast this node's proposal for epoch
+func (m *Manager) propose(epoch uint64, payload string) proposeResult{
+	ei := m.epoch(epoch)
+	if ei == nil{
+		return proposeResult{err: errors.Errorf("acs epoch %d is over and was evicted", epoch)}
+	}
+	if ei.output != nil{
+		return proposeResult{output: ei.output}
+	}
+	if ei.proposed{
+		return proposeResult{err: errors.Errorf("already proposed in acs epoch %d", epoch)}
+	}
+	ei.proposed = true
+	ei.lastActivity = time.Now()
+
+	proposal := ei.view + payload
+	go func(){
+		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.StalledTimeout)
+		defer cancel()
+		if _, err := m.rbc.BroadcastInstance(ctx, rbc0Stream, epoch, proposal); err != nil{
+			m.logger.Error("failed broadcasting acs proposal", zap.Uint64("epoch", epoch), zap.Error(err))
+		}
+	}()
+	return proposeResult{}
+}
+
+
+//other parts of the node can call this to receive the AcsOutputs of this node
+func (m *Manager) SubscribeToMessages() messages.Subscriber{
+	return m.Subscribe(messages.DefaultSubscriptionConfig())
+}
+
+//Subscribe is SubscribeToMessages with a subscription buffer and overflow policy of choice.
+//clients outside the node must get a policy which drops, or a stuck one stalls the epochs
+func (m *Manager) Subscribe(cfg messages.SubscriptionConfig) messages.Subscriber{
+	return m.subscribers.Subscribe(cfg)
+}
+
+//ProposeBatch proposes payload in epoch and returns the output of the epoch.
+//every node proposes once in every epoch. If the epoch is already over, its output is
+//returned right away. If ctx ends first, ctx.Err() is returned and the epoch keeps going.
+func (m *Manager) ProposeBatch(ctx context.Context, epoch uint64, payload string) (messages.AcsOutput, error){
+	//subscribe before proposing, so the output can't be missed
+	sub := m.SubscribeToMessages()
+	defer sub.Close()
+
+	resultC := make(chan proposeResult, 1)
+	m.proposeC <- proposeEvent{epoch: epoch, payload: payload, resultC: resultC}
+	result := <-resultC
+	if result.err != nil{
+		return messages.AcsOutput{}, result.err
+	}
+	if result.output != nil{
+		return *result.output, nil
+	}
+
+	msg, err := messages.WaitFor(ctx, sub, func(msg messages.Message) bool{
+		output, ok := msg.(messages.AcsOutput)
+		return ok && output.Epoch == epoch
+	})
+	switch{
+		case err == nil:
+			return msg.(messages.AcsOutput), nil
+		case err == ctx.Err():
+			m.logger.Debug("acs epoch not over before deadline", zap.Uint64("epoch", epoch))
+		default:
+			m.logger.Error("failed receiving output of PROPOSE_BATCH", zap.Error(err))
+	}
+	return messages.AcsOutput{}, err
+}
@@ -0,0 +1,181 @@
+package acs
+
+import(
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"distry/aba"
+	"distry/internal/testnet"
+	"distry/membership"
+	"distry/messages"
+	"distry/rbc0"
+)
+
+//create acs Managers, with their own rbc0 and aba Managers, for the first running of n nodes.
+//the rest never answer
+func setupManagers(t *testing.T, n, running int) []*Manager{
+	t.Helper()
+	epochs := make([]membership.Epoch, running)
+	for i := range epochs{
+		epochs[i] = networkEpoch(n)
+	}
+	return setupManagersWith(t, epochs, nil)
+}
+
+//epoch of n nodes with the IDs node0, node1, ...
+func networkEpoch(n int) membership.Epoch{
+	epoch := membership.Epoch{Number: 1, N: n}
+	for i := 0; i < n; i++{
+		epoch.Peers = append(epoch.Peers, peer.ID(fmt.Sprintf("node%d", i)))
+	}
+	return epoch
+}
+
+//create the acs Manager of node i in epochs[i], it sees the peers of that epoch.
+//agreement wraps the aba Manager of a node, if not nil
+func setupManagersWith(t *testing.T, epochs []membership.Epoch, agreement func(*aba.Manager) Agreement) []*Manager{
+	t.Helper()
+
+	network := &testnet.Network{}
+	cfg := rbc0.DefaultConfig()
+	managers := make([]*Manager, len(epochs))
+	for i := range managers{
+		node := network.AddNode(peer.ID(fmt.Sprintf("node%d", i)))
+		membershipManager := testnet.Membership{Epoch: epochs[i]}
+		rbc0Manager := rbc0.NewManager(nil, cfg, membershipManager, node)
+		abaManager, err := aba.NewManager(nil, cfg, aba.HashCoin{}, membershipManager, node)
+		if err != nil{
+			t.Fatal(err)
+		}
+		var a Agreement = abaManager
+		if agreement != nil{
+			a = agreement(abaManager)
+		}
+		managers[i] = NewManager(nil, cfg, membershipManager, rbc0Manager, a)
+	}
+	return managers
+}
+
+//every manager proposes in epoch, check they all output the same set of at least n-t proposals
+func proposeAll(t *testing.T, managers []*Manager, n int, epoch uint64){
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	outputs := make([]messages.AcsOutput, len(managers))
+	errs := make(chan error, len(managers))
+	wg := new(sync.WaitGroup)
+	for i, m := range managers{
+		wg.Add(1)
+		go func(i int, m *Manager){
+			defer wg.Done()
+			output, err := m.ProposeBatch(ctx, epoch, fmt.Sprintf("batch of node %d", i))
+			if err != nil{
+				errs <- err
+				return
+			}
+			outputs[i] = output
+		}(i, m)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs{
+		t.Fatal(err)
+	}
+
+	want := outputs[0]
+	if len(want.Proposals) < n - rbc0.MaxFaulty(n){
+		t.Fatalf("epoch %d output %d proposals, want at least %d", epoch, len(want.Proposals), n - rbc0.MaxFaulty(n))
+	}
+	for i, output := range outputs{
+		if output.Epoch != epoch || !reflect.DeepEqual(output.Proposals, want.Proposals){
+			t.Fatalf("node %d output %v in epoch %d, node 0 output %v", i, output.Proposals, output.Epoch, want.Proposals)
+		}
+	}
+}
+
+func TestCommonSubset(t *testing.T){
+	managers := setupManagers(t, 4, 4)
+	for epoch := uint64(1); epoch <= 3; epoch++{
+		proposeAll(t, managers, 4, epoch)
+	}
+}
+
+func TestStuckSubscriber(t *testing.T){
+	//a subscriber which never reads loses outputs, the epochs go on
+	managers := setupManagers(t, 4, 4)
+	sub := managers[0].Subscribe(messages.SubscriptionConfig{Buffer: 1, Policy: messages.DropOldest})
+	defer sub.Close()
+	for epoch := uint64(1); epoch <= 3; epoch++{
+		proposeAll(t, managers, 4, epoch)
+	}
+	if sub.Dropped() == 0{
+		t.Fatal("the stuck subscriber lost no output")
+	}
+}
+
+func TestCommonSubsetSilentNode(t *testing.T){
+	//t=1 of the n=4 nodes never proposes nor answers
+	managers := setupManagers(t, 4, 3)
+	proposeAll(t, managers, 4, 1)
+}
+
+func TestProposalOfAnotherView(t *testing.T){
+	//node3 sees a 5th peer, so the others leave its proposal out and it can't decide with them
+	epochs := []membership.Epoch{networkEpoch(4), networkEpoch(4), networkEpoch(4), networkEpoch(5)}
+	managers := setupManagersWith(t, epochs, nil)
+
+	done := make(chan struct{})
+	go func(){
+		defer close(done)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, err := managers[3].ProposeBatch(ctx, 1, "batch of node 3"); err == nil{
+			t.Error("node3 decided without a quorum of its view")
+		}
+	}()
+	proposeAll(t, managers[:3], 4, 1)
+
+	output, err := managers[0].ProposeBatch(context.Background(), 1, "") //the epoch is over
+	if err != nil{
+		t.Fatal(err)
+	}
+	for _, proposal := range output.Proposals{
+		if proposal.ProposerID == peer.ID("node3").String(){
+			t.Fatal("the proposal of another view made it into the output")
+		}
+	}
+	<-done
+}
+
+//flakyAgreement fails the first Agree of every session
+type flakyAgreement struct{
+	Agreement
+	failed		map[string]bool
+	failedLock	sync.Mutex
+}
+
+func (a *flakyAgreement) Agree(ctx context.Context, sessionID string, value bool) (messages.AbaDecision, error){
+	a.failedLock.Lock()
+	failed := a.failed[sessionID]
+	a.failed[sessionID] = true
+	a.failedLock.Unlock()
+	if !failed{
+		return messages.AbaDecision{}, context.DeadlineExceeded
+	}
+	return a.Agreement.Agree(ctx, sessionID, value)
+}
+
+func TestAgreementRetried(t *testing.T){
+	epochs := []membership.Epoch{networkEpoch(4), networkEpoch(4), networkEpoch(4), networkEpoch(4)}
+	managers := setupManagersWith(t, epochs, func(m *aba.Manager) Agreement{
+		return &flakyAgreement{Agreement: m, failed: make(map[string]bool)}
+	})
+	proposeAll(t, managers, 4, 1)
+}
@@ -0,0 +1,57 @@
+package acs
+
+import(
+	"time"
+
+	"go.uber.org/zap"
+
+	"distry/internal/retention"
+)
+
+//current epoch counts of the node, served by expvar under /debug/vars
+var metrics = retention.NewMetrics("acs")
+
+//evict epochs which are over and too old or too many, and epochs which stalled, like rbc0 does.
+//evicted epochs are remembered in the low-water mark, so late proposals don't start them anew.
+func (m *Manager) gc(now time.Time){
+	evictedOver := retention.Expired(now, m.cfg.AcceptedMaxAge, m.cfg.AcceptedMaxCount, len(m.outputOrder), func(i int) time.Time{
+		return m.epochInfoMap[m.outputOrder[i]].outputAt
+	})
+	for _, epoch := range m.outputOrder[:evictedOver]{
+		m.evict(epoch)
+	}
+	m.outputOrder = m.outputOrder[evictedOver:]
+
+	evictedStalled := 0
+	for epoch, ei := range m.epochInfoMap{
+		if ei.output == nil && retention.Stalled(now, ei.lastActivity, m.cfg.StalledTimeout){
+			m.logger.Info("evicting stalled acs epoch",
+				zap.Uint64("epoch", epoch),
+				zap.Int("decisions", len(ei.decisions)),
+			)
+			m.evict(epoch)
+			evictedStalled++
+		}
+	}
+
+	metrics.Add("epochs_evicted_over", int64(evictedOver))
+	metrics.Add("epochs_evicted_stalled", int64(evictedStalled))
+	m.updateMetrics()
+}
+
+//only epochs this node proposed in or which are over are remembered as evicted:
+//any proposer can start an epoch far ahead of the others with its proposal
+func (m *Manager) evict(epoch uint64){
+	ei := m.epochInfoMap[epoch]
+	delete(m.epochInfoMap, epoch)
+	if ei.proposed || ei.output != nil{
+		m.lowWater.Evict("", epoch)
+	}
+}
+
+//publish the current epoch counts
+func (m *Manager) updateMetrics(){
+	over := len(m.outputOrder)
+	metrics.SetGauge("epochs_active", len(m.epochInfoMap) - over)
+	metrics.SetGauge("epochs_over", over)
+}
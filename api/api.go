@@ -2,11 +2,17 @@ package api
 
 import(
 	"context"
+	"time"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"distry/messages"
 	apigen "distry/proto_gen/api"
 	"distry/node"
+	"distry/rbc0"
 )
 
 type Server struct{
@@ -32,16 +38,299 @@ func (s *Server) Ping(_ context.Context, _ *apigen.PingRequest) (*apigen.PingRes
 }
 
 //Rbc0
-func (s *Server) Rbc0(_ context.Context, request *apigen.Rbc0Request) (*apigen.Rbc0Response, error){
-	s.logger.Info("handling Rbc0")
+func (s *Server) Rbc0(ctx context.Context, request *apigen.Rbc0Request) (*apigen.Rbc0Response, error){
+	s.logger.Info("handling Rbc0", zap.String("variant", request.Variant.String()))
 
-	done, err := s.node.Rbc0(request.Payload)
+	if request.TimeoutMs > 0{
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(request.TimeoutMs) * time.Millisecond)
+		defer cancel()
+	}
+
+	var delivery *apigen.Rbc0Delivery
+	var err error
+	switch request.Variant{
+		case apigen.Rbc0Request_CONSISTENT:
+			var cbcDelivery messages.CbcDelivery
+			cbcDelivery, err = s.node.Cbc(ctx, request.Payload)
+			delivery = cbcDeliveryToProtobuf(cbcDelivery)
+		default:
+			var rbc0Delivery messages.Rbc0Delivery
+			rbc0Delivery, err = s.node.Rbc0(ctx, request.Payload)
+			delivery = rbc0DeliveryToProtobuf(rbc0Delivery)
+	}
 	if err != nil{
+		var notAccepted *rbc0.NotAcceptedError
+		if errors.As(err, &notAccepted){
+			if errors.Is(notAccepted.Err, context.DeadlineExceeded) && request.TimeoutMs > 0{
+				//the caller asked for the round, not an error, after the timeout
+				return &apigen.Rbc0Response{ProtocolId: notAccepted.ProtocolID}, nil
+			}
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
 		s.logger.Error("failed Rbc0", zap.Error(err))
-		return &apigen.Rbc0Response{Done: done}, err
+		return nil, err
+	}
+
+	return &apigen.Rbc0Response{
+		ProtocolId:	delivery.ProtocolId,
+		Delivery:	delivery,
+	}, nil
+}
+
+//deliveries of the consistent variant are not logged, so they carry no Seq but their certificate
+func cbcDeliveryToProtobuf(delivery messages.CbcDelivery) *apigen.Rbc0Delivery{
+	certificate := make([]*apigen.CbcEcho, len(delivery.Certificate))
+	for i, echo := range delivery.Certificate{
+		certificate[i] = &apigen.CbcEcho{SignerId: echo.SignerID, Signature: echo.Signature}
+	}
+	return &apigen.Rbc0Delivery{
+		SenderId:				delivery.SenderID,
+		ProtocolId:				delivery.ProtocolID,
+		Payload:					delivery.Payload,
+		AcceptedAtUnixNano:	delivery.AcceptedAt.UnixNano(),
+		Certificate:			certificate,
+	}
+}
+
+func rbc0DeliveryToProtobuf(delivery messages.Rbc0Delivery) *apigen.Rbc0Delivery{
+	return &apigen.Rbc0Delivery{
+		SenderId:				delivery.SenderID,
+		ProtocolId:				delivery.ProtocolID,
+		Payload:					delivery.Payload,
+		Seq:						delivery.Seq,
+		AcceptedAtUnixNano:	delivery.AcceptedAt.UnixNano(),
+	}
+}
+
+//GetRbc0Round
+func (s *Server) GetRbc0Round(_ context.Context, request *apigen.GetRbc0RoundRequest) (*apigen.GetRbc0RoundResponse, error){
+	s.logger.Info("handling GetRbc0Round")
+
+	round, err := s.node.GetRbc0Round(request.ProtocolId)
+	if err != nil{
+		if err == node.ErrUnknownRound{
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		s.logger.Error("failed GetRbc0Round", zap.Error(err))
+		return nil, err
+	}
+
+	return &apigen.GetRbc0RoundResponse{
+		ProtocolId:	round.ProtocolID,
+		Stage:		round.Stage,
+		Accepted:	round.Accepted,
+		Payload:		round.Payload,
+		Epoch:		round.Epoch,
+		N:				uint32(round.N),
+		T:				uint32(round.T),
+	}, nil
+}
+
+
+//SubscribeDeliveries
+func (s *Server) SubscribeDeliveries(request *apigen.SubscribeDeliveriesRequest, stream apigen.Api_SubscribeDeliveriesServer) error{
+	s.logger.Info("handling SubscribeDeliveries", zap.Uint64("fromSeq", request.FromSeq))
+
+	replay, sub, err := s.node.SubscribeDeliveries(request.FromSeq)
+	if err != nil{
+		s.logger.Error("failed SubscribeDeliveries", zap.Error(err))
+		return err
+	}
+	//closing the subscription makes sub.Next return, which ends the stream
+	go func(){
+		<-stream.Context().Done()
+		sub.Close()
+	}()
+
+	var lastSeq uint64 //Seq of the last delivery sent, later ones may repeat the replay
+	for _, delivery := range replay{
+		if err := stream.Send(rbc0DeliveryToProtobuf(delivery)); err != nil{
+			return err
+		}
+		lastSeq = delivery.Seq
+	}
+
+	for{
+		msg, err := sub.Next()
+		if err != nil || msg == nil{
+			return nil
+		}
+		delivery, ok := msg.(messages.Rbc0Delivery)
+		if !ok || delivery.Seq <= lastSeq{
+			continue
+		}
+		if err := stream.Send(rbc0DeliveryToProtobuf(delivery)); err != nil{
+			return err
+		}
+		lastSeq = delivery.Seq
+	}
+}
+
+
+//Avid
+func (s *Server) Avid(ctx context.Context, request *apigen.AvidRequest) (*apigen.AvidResponse, error){
+	s.logger.Info("handling Avid", zap.Int("payloadSize", len(request.Payload)))
+
+	if request.TimeoutMs > 0{
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(request.TimeoutMs) * time.Millisecond)
+		defer cancel()
+	}
+
+	delivery, err := s.node.Avid(ctx, request.Payload)
+	if err != nil{
+		var notAccepted *rbc0.NotAcceptedError
+		if errors.As(err, &notAccepted){
+			if errors.Is(notAccepted.Err, context.DeadlineExceeded) && request.TimeoutMs > 0{
+				return &apigen.AvidResponse{ProtocolId: notAccepted.ProtocolID}, nil
+			}
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		s.logger.Error("failed Avid", zap.Error(err))
+		return nil, err
+	}
+
+	return &apigen.AvidResponse{
+		ProtocolId:	delivery.ProtocolID,
+		Delivery:	&apigen.AvidDelivery{
+			SenderId:				delivery.SenderID,
+			ProtocolId:				delivery.ProtocolID,
+			Payload:					delivery.Payload,
+			AcceptedAtUnixNano:	delivery.AcceptedAt.UnixNano(),
+		},
+	}, nil
+}
+
+
+//Agree
+func (s *Server) Agree(ctx context.Context, request *apigen.AgreeRequest) (*apigen.AgreeResponse, error){
+	s.logger.Info("handling Agree", zap.String("sessionID", request.SessionId))
+
+	if request.SessionId == ""{
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	if request.TimeoutMs > 0{
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(request.TimeoutMs) * time.Millisecond)
+		defer cancel()
+	}
+
+	decision, err := s.node.Agree(ctx, request.SessionId, request.Bit)
+	if err != nil{
+		if errors.Is(err, context.DeadlineExceeded){
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		if errors.Is(err, node.ErrNoCoinKeys){
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		s.logger.Error("failed Agree", zap.Error(err))
+		return nil, err
+	}
+
+	return &apigen.AgreeResponse{
+		Bit:		decision.Value,
+		Round:	decision.Round,
+	}, nil
+}
+
+
+//ProposeBatch
+func (s *Server) ProposeBatch(ctx context.Context, request *apigen.ProposeBatchRequest) (*apigen.EpochOutput, error){
+	s.logger.Info("handling ProposeBatch", zap.Uint64("epoch", request.Epoch))
+
+	if request.TimeoutMs > 0{
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(request.TimeoutMs) * time.Millisecond)
+		defer cancel()
+	}
+
+	output, err := s.node.ProposeBatch(ctx, request.Epoch, request.Payload)
+	if err != nil{
+		if errors.Is(err, context.DeadlineExceeded){
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		if errors.Is(err, node.ErrNoCoinKeys){
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		s.logger.Error("failed ProposeBatch", zap.Error(err))
+		return nil, err
 	}
 
-	return &apigen.Rbc0Response{Done: done}, nil
+	return acsOutputToProtobuf(output), nil
 }
 
+func acsOutputToProtobuf(output messages.AcsOutput) *apigen.EpochOutput{
+	proposals := make([]*apigen.Proposal, len(output.Proposals))
+	for i, proposal := range output.Proposals{
+		proposals[i] = &apigen.Proposal{
+			ProposerId:	proposal.ProposerID,
+			Payload:		proposal.Payload,
+		}
+	}
+	return &apigen.EpochOutput{
+		Epoch:		output.Epoch,
+		Proposals:	proposals,
+	}
+}
+
+//SubscribeEpochOutputs
+func (s *Server) SubscribeEpochOutputs(_ *apigen.SubscribeEpochOutputsRequest, stream apigen.Api_SubscribeEpochOutputsServer) error{
+	s.logger.Info("handling SubscribeEpochOutputs")
+
+	sub, err := s.node.SubscribeEpochOutputs()
+	if err != nil{
+		if errors.Is(err, node.ErrNoCoinKeys){
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		s.logger.Error("failed SubscribeEpochOutputs", zap.Error(err))
+		return err
+	}
+	//closing the subscription makes sub.Next return, which ends the stream
+	go func(){
+		<-stream.Context().Done()
+		sub.Close()
+	}()
+
+	for{
+		msg, err := sub.Next()
+		if err != nil || msg == nil{
+			return nil
+		}
+		output, ok := msg.(messages.AcsOutput)
+		if !ok{
+			continue
+		}
+		if err := stream.Send(acsOutputToProtobuf(output)); err != nil{
+			return err
+		}
+	}
+}
+
+
+//RunDKG
+func (s *Server) RunDKG(ctx context.Context, request *apigen.RunDKGRequest) (*apigen.RunDKGResponse, error){
+	s.logger.Info("handling RunDKG", zap.Uint64("session", request.Session))
+
+	if request.TimeoutMs > 0{
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(request.TimeoutMs) * time.Millisecond)
+		defer cancel()
+	}
 
+	result, err := s.node.RunDKG(ctx, request.Session)
+	if err != nil{
+		if errors.Is(err, context.DeadlineExceeded){
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		s.logger.Error("failed RunDKG", zap.Error(err))
+		return nil, err
+	}
+
+	return &apigen.RunDKGResponse{
+		Session:		result.Session,
+		PublicKey:	result.Keys.PublicKey.Bytes(),
+		Index:		result.Keys.Index,
+		Qualified:	result.Qualified,
+	}, nil
+}
@@ -0,0 +1,494 @@
+package avid
+
+import(
+	"bytes"
+	"context"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"distry/internal/retention"
+	"distry/membership"
+	"distry/messages"
+	genmsg "distry/proto_gen/messages"
+	"distry/rbc0"
+)
+
+//stages of an avid round, they double as the types of avid messages.
+//see proto/messages.proto
+const(
+	stageVal			uint32 = 1
+	stageEcho		uint32 = 2
+	stageReady		uint32 = 3
+	stageAccepted	uint32 = 4
+)
+
+//fragments and votes received for one merkle root of an avid round
+type rootInfo struct{
+	//map [ FRAGMENT_INDEX -> FRAGMENT ]
+	//fragments carried by the ECHOs, each one checked against the root
+	fragments	map[int][]byte
+	//set of SENDER_IDs which sent an ECHO / READY for this root
+	echos		map[string]struct{}
+	readys	map[string]struct{}
+}
+
+//struct to keep info on an avid round
+//round begins when some node disperses a payload and ends when it is rebuilt
+type roundInfo struct{
+	//stage this node is in in regards to an avid round.
+	//'2':ECHO, '3':READY, '4':ACCEPTED
+	localStage uint32
+	//membership epoch the round started in on this node. n, t and the fragment
+	//assigned to every peer are pinned to it.
+	epoch uint64
+	n, t int
+	peers map[string]int //map [ PEER_ID -> FRAGMENT_INDEX ]
+	initiator string
+	acceptedPayload []byte
+	lastActivity time.Time
+	acceptedAt time.Time
+
+	//map [ ROOT -> rootInfo ]
+	//an equivocating initiator can disperse fragments of different trees,
+	//so echos and readys are counted separately for every root.
+	roots map[string]*rootInfo
+
+	//map [ SENDER_ID -> set of stages ]
+	//only the first message of each type from a peer is counted
+	stagesOfPeer map[string]map[uint32]bool
+}
+
+//Omni is the part of omni.Manager avid relies on
+type Omni interface{
+	ID() peer.ID
+	OmniPublisher(messages.Message) error
+	SendOrPublish(peer.ID, messages.Message)
+	Register(messages.Codec)
+}
+
+//Membership is the part of membership.Manager avid relies on
+type Membership interface{
+	Current() membership.Epoch
+}
+
+//events handled by the event loop, see eventLoop
+type broadcastEvent struct{
+	payload		[]byte
+	resultC		chan<- broadcastResult
+}
+type broadcastResult struct{
+	protocolID	string //of the started round
+	err			error
+}
+
+//Manager runs asynchronous verifiable information dispersal (cachin & tessaro).
+//instead of sending the whole payload in every message like rbc0 does, the initiator
+//codes it into n fragments, sends each peer one fragment with its merkle proof (VAL)
+//and peers echo only their fragment. Any n-2t fragments rebuild the payload.
+type Manager struct{
+	logger	*zap.Logger
+	cfg		rbc0.Config
+	nodeID	string
+	omniManager Omni
+	membershipManager Membership
+
+	msgC			chan messages.MsgAvid //avid messages received from the omni network
+	broadcastC	chan broadcastEvent
+
+	//map [ PROTOCOL_ID -> roundInfo ]
+	roundInfoMap	map[string]*roundInfo
+	//PROTOCOL_IDs of the accepted rounds still in roundInfoMap, in the order they were accepted
+	acceptedOrder	[]string
+	//evicted rounds by rbc0.LowWaterKey and counter, see rbc0
+	lowWater			*retention.LowWater
+	//counter of the protocolIDs of the rounds this node starts, seeded from the clock
+	protocolCnt uint64
+
+	//this Manager sends AvidDeliveries via msgPublisher to the subscribers
+	msgPublisher	messages.Publisher
+	subscribers		messages.Fanout
+}
+
+func NewManager(logger *zap.Logger, cfg rbc0.Config, membershipManager Membership, omniManager Omni) *Manager{
+	if logger == nil{
+		logger = zap.NewNop()
+	}
+	cfg = cfg.WithDefaults()
+
+	pub, sub := messages.NewSubscription()
+
+	m := &Manager{
+		logger:			logger,
+		cfg:				cfg,
+		nodeID:			omniManager.ID().String(),
+		omniManager:	omniManager,
+		membershipManager:	membershipManager,
+		msgC:				make(chan messages.MsgAvid),
+		broadcastC:		make(chan broadcastEvent),
+		roundInfoMap:	make(map[string]*roundInfo),
+		acceptedOrder:	make([]string, 0),
+		lowWater:		retention.NewLowWater(cfg.EvictedWindow),
+		protocolCnt:	uint64(time.Now().UnixNano()),
+		msgPublisher:	pub,
+	}
+
+	go m.subscribers.Run(sub, func(dropped uint64){ metrics.Add("messages_dropped", int64(dropped)) })
+	go m.eventLoop()
+	m.omniManager.Register(messages.Codec{
+		Type:				genmsg.Message_AVID,
+		Marshal:			messages.MarshalAvid,
+		Unmarshal:		messages.UnmarshalAvid,
+		Handler:			m.handleOmniMsg,
+	})
+	return m
+}
+
+//the only goroutine which reads or changes protocol state
+func (m *Manager) eventLoop(){
+	gcTicker := time.NewTicker(m.cfg.GCInterval)
+	defer gcTicker.Stop()
+
+	for{
+		select{
+			case msg := <-m.msgC:
+				m.handleMsg(msg)
+			case ev := <-m.broadcastC:
+				protocolID, err := m.startRound(ev.payload)
+				ev.resultC <- broadcastResult{protocolID: protocolID, err: err}
+			case now := <-gcTicker.C:
+				m.gc(now)
+		}
+	}
+}
+
+//Handler of the codec registered with omni, pass on the received messages to the event loop
+func (m *Manager) handleOmniMsg(in messages.Message){
+	msg, ok := messages.Unwrap(in).(messages.MsgAvid)
+	if !ok{
+		return
+	}
+	m.msgC <- msg
+}
+
+func (m *Manager) handleMsg(msg messages.MsgAvid){
+	if msg.Type < stageVal || msg.Type > stageReady{
+		m.logger.Warn("avid discarding msg of unknown type", zap.Uint32("type", msg.Type))
+		return
+	}
+	//VALs are sent over the omni topic like everything else, so every node sees all of them.
+	//only the one addressed to this node counts.
+	if msg.Type == stageVal && msg.RecipientID != m.nodeID{
+		return
+	}
+
+	ri, exists := m.roundInfoMap[msg.ProtocolID]
+	if !exists{
+		if m.isLate(msg.ProtocolID){
+			metrics.Add("late_msgs_dropped", 1)
+			return
+		}
+		if rbc0.IsOutsider(m.membershipManager.Current(), msg.ProtocolID){
+			metrics.Add("outsider_msgs_dropped", 1)
+			return
+		}
+		ri = m.instantiateRoundInfo(msg.ProtocolID)
+	}
+	if ri.localStage == stageAccepted{
+		return
+	}
+	ri.lastActivity = time.Now()
+
+	if msg.Type == stageVal && msg.SenderID != ri.initiator{
+		m.logger.Warn("avid discarding VAL not sent by the initiator of the round",
+			zap.String("protocolID", msg.ProtocolID),
+			zap.String("senderID", msg.SenderID),
+		)
+		return
+	}
+	if msg.Type != stageReady && !m.validFragment(ri, msg){
+		m.logger.Warn("avid discarding msg with invalid fragment",
+			zap.String("protocolID", msg.ProtocolID),
+			zap.String("senderID", msg.SenderID),
+			zap.Uint32("type", msg.Type),
+		)
+		return
+	}
+	if _, isPeer := ri.peers[msg.SenderID]; msg.Type == stageReady && !isPeer{
+		m.logger.Warn("avid discarding READY of a node which is no peer of the round's epoch",
+			zap.String("protocolID", msg.ProtocolID),
+			zap.String("senderID", msg.SenderID),
+		)
+		return
+	}
+
+	senderStages, exists := ri.stagesOfPeer[msg.SenderID]
+	if !exists{
+		senderStages = make(map[uint32]bool)
+		ri.stagesOfPeer[msg.SenderID] = senderStages
+	}
+	if senderStages[msg.Type]{
+		return
+	}
+	senderStages[msg.Type] = true
+
+	root := ri.root(msg.Root)
+	switch msg.Type{
+		case stageVal:
+			//ECHO own fragment of the first VAL, so every peer can collect it
+			if ri.localStage < stageEcho{
+				m.vote(msg.ProtocolID, stageEcho, msg.Root, msg.Fragment, msg.Proof)
+			}
+		case stageEcho:
+			root.fragments[int(msg.Index)] = msg.Fragment
+			root.echos[msg.SenderID] = struct{}{}
+		case stageReady:
+			root.readys[msg.SenderID] = struct{}{}
+	}
+
+	m.checkRound(msg.ProtocolID, msg.Root)
+}
+
+//a fragment is valid if it is the one assigned to the node it is meant for
+//(the recipient of a VAL, the sender of an ECHO) and the proof ties it to the root
+func (m *Manager) validFragment(ri *roundInfo, msg messages.MsgAvid) bool{
+	owner := msg.SenderID
+	if msg.Type == stageVal{
+		owner = msg.RecipientID
+	}
+	index, exists := ri.peers[owner]
+	if !exists || int(msg.Index) != index || int(msg.Shards) != ri.n{
+		return false
+	}
+	return verifyProof(msg.Root, ri.n, index, msg.Fragment, msg.Proof)
+}
+
+func (m *Manager) instantiateRoundInfo(protocolID string) *roundInfo{
+	epoch := m.membershipManager.Current()
+
+	var ri roundInfo
+	ri.epoch = epoch.Number
+	ri.n = epoch.N
+	ri.t = rbc0.MaxFaulty(epoch.N)
+	ri.peers = make(map[string]int)
+	for i, p := range epoch.Peers{
+		ri.peers[p.String()] = i
+	}
+	ri.initiator, _, _, _ = rbc0.ParseProtocolID(protocolID)
+	ri.lastActivity = time.Now()
+	ri.roots = make(map[string]*rootInfo)
+	ri.stagesOfPeer = make(map[string]map[uint32]bool)
+	m.roundInfoMap[protocolID] = &ri
+	m.updateMetrics()
+
+	m.logger.Debug("new avid round",
+		zap.String("protocolID", protocolID),
+		zap.Uint64("epoch", ri.epoch),
+		zap.Int("n", ri.n),
+		zap.Int("t", ri.t),
+	)
+	return &ri
+}
+
+//return the fragments and votes of root, create them if this is its first message
+func (ri *roundInfo) root(root []byte) *rootInfo{
+	key := hex.EncodeToString(root)
+	info, exists := ri.roots[key]
+	if !exists{
+		info = &rootInfo{
+			fragments:	make(map[int][]byte),
+			echos:		make(map[string]struct{}),
+			readys:		make(map[string]struct{}),
+		}
+		ri.roots[key] = info
+	}
+	return info
+}
+
+//after a message for a round was received, check whether the round can move forward for root.
+//READY is sent on n-t ECHOs or t+1 READYs, the payload is rebuilt once there are
+//2t+1 READYs and n-2t fragments
+func (m *Manager) checkRound(protocolID string, root []byte){
+	ri := m.roundInfoMap[protocolID]
+	info := ri.root(root)
+
+	n, t := ri.n, ri.t
+	if ri.localStage < stageReady && (len(info.echos) >= n-t || len(info.readys) >= t+1){
+		m.vote(protocolID, stageReady, root, nil, nil)
+	}
+	if ri.localStage == stageReady && len(info.readys) >= 2*t+1 && len(info.fragments) >= n-2*t{
+		m.accept(protocolID, root, info.fragments)
+	}
+}
+
+//move the local node to stage for root, broadcast it and count it as this node's vote
+func (m *Manager) vote(protocolID string, stage uint32, root, fragment []byte, proof [][]byte){
+	ri := m.roundInfoMap[protocolID]
+	ri.localStage = stage
+	index := ri.peers[m.nodeID]
+	m.broadcast(messages.MsgAvid{
+		ProtocolID:	protocolID,
+		Type:			stage,
+		Root:			root,
+		Shards:		uint32(ri.n),
+		Index:		uint32(index),
+		Fragment:	fragment,
+		Proof:		proof,
+	})
+
+	info := ri.root(root)
+	switch stage{
+		case stageEcho:
+			info.fragments[index] = fragment
+			info.echos[m.nodeID] = struct{}{}
+		case stageReady:
+			info.readys[m.nodeID] = struct{}{}
+	}
+}
+
+//rebuild the payload and check it against the root by coding it anew.
+//if the initiator dispersed fragments which are not the coding of any payload, every correct
+//node finds the same mismatch, and the round is accepted with a nil payload.
+func (m *Manager) accept(protocolID string, root []byte, fragments map[int][]byte){
+	ri := m.roundInfoMap[protocolID]
+
+	var payload []byte
+	c, err := newCodec(ri.n-2*ri.t, ri.n)
+	if err == nil{
+		payload, err = c.decode(fragments)
+	}
+	var reFragments [][]byte
+	if err == nil{
+		reFragments, err = c.encode(payload)
+	}
+	if err == nil{
+		if reRoot, _ := merkleTree(reFragments); !bytes.Equal(reRoot, root){
+			err = errInconsistent
+		}
+	}
+	if err != nil{
+		m.logger.Warn("avid round dispersed an invalid payload",
+			zap.String("protocolID", protocolID),
+			zap.String("initiator", ri.initiator),
+			zap.Error(err),
+		)
+		payload = nil
+		metrics.Add("invalid_payloads", 1)
+	}
+
+	ri.localStage = stageAccepted
+	ri.acceptedPayload = payload
+	ri.acceptedAt = time.Now()
+	m.acceptedOrder = append(m.acceptedOrder, protocolID)
+	m.updateMetrics()
+	m.logger.Info("avid round ACCEPTADO",
+		zap.String("protocolID", protocolID),
+		zap.Int("payloadSize", len(payload)),
+		zap.Uint64("epoch", ri.epoch),
+	)
+
+	msg := messages.AvidDelivery{
+		SenderID:	ri.initiator,
+		ProtocolID:	protocolID,
+		Payload:		payload,
+		AcceptedAt:	ri.acceptedAt,
+	}
+	if err := m.msgPublisher.Publish(msg); err != nil{
+		m.logger.Error("failed passing avid message to the subscribers")
+	}
+	//cleanup round resources
+	ri.roots = nil
+	ri.stagesOfPeer = nil
+}
+
+func (m *Manager) broadcast(msg messages.MsgAvid){
+	if err := m.omniManager.OmniPublisher(&msg); err != nil{
+		m.logger.Error("sending avid msg in round FAILED", zap.String("protocolID", msg.ProtocolID))
+	}
+}
+
+//disperse payload in a new round, return its protocolID
+func (m *Manager) startRound(payload []byte) (string, error){
+	protocolID := m.nodeID + "_" + strconv.FormatUint(m.protocolCnt, 10)
+	m.protocolCnt++
+
+	ri := m.instantiateRoundInfo(protocolID)
+	c, err := newCodec(ri.n-2*ri.t, ri.n)
+	var fragments [][]byte
+	if err == nil{
+		fragments, err = c.encode(payload)
+	}
+	if err != nil{
+		//no VAL was sent, so no one else knows the round
+		delete(m.roundInfoMap, protocolID)
+		m.updateMetrics()
+		return "", errors.Wrapf(err, "coding the payload of avid round %s", protocolID)
+	}
+	root, proofs := merkleTree(fragments)
+
+	//send every peer its own fragment
+	for peerID, index := range ri.peers{
+		msg := messages.MsgAvid{
+			SenderID:		m.nodeID,
+			ProtocolID:		protocolID,
+			Type:				stageVal,
+			RecipientID:	peerID,
+			Root:				root,
+			Shards:			uint32(ri.n),
+			Index:			uint32(index),
+			Fragment:		fragments[index],
+			Proof:			proofs[index],
+		}
+		if peerID == m.nodeID{
+			//own messages don't come back from the omni network
+			m.handleMsg(msg)
+		} else if id, err := peer.Decode(peerID); err == nil{
+			//only the recipient gets its VAL, or everyone over the omni topic if no direct stream works
+			m.omniManager.SendOrPublish(id, &msg)
+		} else{
+			m.broadcast(msg)
+		}
+	}
+	m.logger.Debug("sending avid VALs: DONE", zap.String("protocolID", protocolID))
+
+	return protocolID, nil
+}
+
+//other parts of the node can call this to receive the AvidDeliveries of this node
+func (m *Manager) SubscribeToMessages() messages.Subscriber{
+	return m.subscribers.Subscribe(messages.DefaultSubscriptionConfig())
+}
+
+//Broadcast disperses payload in a new round and returns its delivery once it is accepted.
+//if ctx ends first, a *rbc0.NotAcceptedError carrying the protocolID of the round is returned.
+func (m *Manager) Broadcast(ctx context.Context, payload []byte) (messages.AvidDelivery, error){
+	//subscribe before the round starts, so its delivery can't be missed
+	sub := m.SubscribeToMessages()
+	defer sub.Close()
+
+	resultC := make(chan broadcastResult, 1)
+	m.broadcastC <- broadcastEvent{payload: payload, resultC: resultC}
+	result := <-resultC
+	if result.err != nil{
+		return messages.AvidDelivery{}, result.err
+	}
+	protocolID := result.protocolID
+
+	msg, err := messages.WaitFor(ctx, sub, func(msg messages.Message) bool{
+		delivery, ok := msg.(messages.AvidDelivery)
+		return ok && delivery.ProtocolID == protocolID
+	})
+	switch{
+		case err == nil:
+			return msg.(messages.AvidDelivery), nil
+		case err == ctx.Err():
+			return messages.AvidDelivery{}, &rbc0.NotAcceptedError{ProtocolID: protocolID, Err: err}
+		default:
+			m.logger.Error("failed receiving message dispersed by BROADCAST", zap.Error(err))
+			return messages.AvidDelivery{}, err
+	}
+}
@@ -0,0 +1,165 @@
+package avid
+
+import(
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"distry/internal/testnet"
+	"distry/membership"
+	"distry/messages"
+	"distry/rbc0"
+)
+
+func TestCodec(t *testing.T){
+	c, err := newCodec(2, 4)
+	if err != nil{
+		t.Fatal(err)
+	}
+	payload := []byte("a payload which does not divide evenly")
+	fragments, err := c.encode(payload)
+	if err != nil{
+		t.Fatal(err)
+	}
+	root, proofs := merkleTree(fragments)
+
+	for i, fragment := range fragments{
+		if !verifyProof(root, 4, i, fragment, proofs[i]){
+			t.Fatalf("proof of fragment %d does not verify", i)
+		}
+	}
+	if verifyProof(root, 4, 1, fragments[0], proofs[0]){
+		t.Fatal("fragment 0 verified as fragment 1")
+	}
+
+	//every pair of fragments rebuilds the payload
+	for i := range fragments{
+		for j := i+1; j < len(fragments); j++{
+			decoded, err := c.decode(map[int][]byte{i: fragments[i], j: fragments[j]})
+			if err != nil{
+				t.Fatalf("decoding from fragments %d, %d: %v", i, j, err)
+			}
+			if !bytes.Equal(decoded, payload){
+				t.Fatalf("fragments %d, %d decoded into %q", i, j, decoded)
+			}
+		}
+	}
+}
+
+//create nodesNum nodes of an epoch, of which the first skipped run no Manager.
+//the deliveries of every Manager are sent on the returned channel.
+func setupManagers(t *testing.T, nodesNum, skipped int) ([]*testnet.Node, []*Manager, <-chan messages.AvidDelivery){
+	t.Helper()
+
+	network := &testnet.Network{}
+	nodes := make([]*testnet.Node, nodesNum)
+	epoch := membership.Epoch{Number: 1, N: nodesNum}
+	for i := range nodes{
+		nodes[i] = network.AddNode(peer.ID(fmt.Sprintf("node%d", i)))
+		epoch.Peers = append(epoch.Peers, nodes[i].ID())
+	}
+	managers := make([]*Manager, nodesNum)
+	//subscriptions block the sender until read, so every node's deliveries are read meanwhile
+	deliveries := make(chan messages.AvidDelivery, nodesNum)
+	for i := skipped; i < nodesNum; i++{
+		managers[i] = NewManager(nil, rbc0.DefaultConfig(), testnet.Membership{Epoch: epoch}, nodes[i])
+		go func(sub messages.Subscriber){
+			msg, err := sub.Next()
+			if err == nil && msg != nil{
+				deliveries <- msg.(messages.AvidDelivery)
+			}
+		}(managers[i].SubscribeToMessages())
+	}
+	return nodes, managers, deliveries
+}
+
+func TestBroadcast(t *testing.T){
+	const nodesNum = 4
+	_, managers, deliveries := setupManagers(t, nodesNum, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	payload := bytes.Repeat([]byte("large payload "), 1000)
+	delivery, err := managers[0].Broadcast(ctx, payload)
+	if err != nil{
+		t.Fatal(err)
+	}
+	if !bytes.Equal(delivery.Payload, payload){
+		t.Fatalf("initiator delivered %d bytes, want %d", len(delivery.Payload), len(payload))
+	}
+
+	for i := 0; i < nodesNum; i++{
+		select{
+			case d := <-deliveries:
+				if d.ProtocolID != delivery.ProtocolID || !bytes.Equal(d.Payload, payload){
+					t.Fatalf("round %s delivered with %d bytes", d.ProtocolID, len(d.Payload))
+				}
+			case <-ctx.Done():
+				t.Fatalf("only %d of %d nodes delivered", i, nodesNum)
+		}
+	}
+}
+
+func TestBroadcastCodingError(t *testing.T){
+	//the fragments of 200 nodes don't fit the cauchy matrix of GF(2^8)
+	_, managers, _ := setupManagers(t, 200, 199)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := managers[199].Broadcast(ctx, []byte("payload"))
+	if err == nil{
+		t.Fatal("a payload which can't be coded was broadcast")
+	}
+	if _, notAccepted := err.(*rbc0.NotAcceptedError); notAccepted{
+		t.Fatalf("got %v, want the coding error right away", err)
+	}
+}
+
+func TestInconsistentFragments(t *testing.T){
+	const nodesNum = 4
+	//node0 is a faulty initiator, which disperses fragments that are not the coding of any payload
+	nodes, _, deliveries := setupManagers(t, nodesNum, 1)
+
+	c, err := newCodec(2, nodesNum)
+	if err != nil{
+		t.Fatal(err)
+	}
+	fragments, err := c.encode([]byte("a payload"))
+	if err != nil{
+		t.Fatal(err)
+	}
+	fragments[3][len(fragments[3])-1] ^= 0xff
+	root, proofs := merkleTree(fragments)
+
+	protocolID := nodes[0].ID().String() + "_1"
+	for i := 1; i < nodesNum; i++{
+		nodes[0].SendOrPublish(nodes[i].ID(), &messages.MsgAvid{
+			ProtocolID:		protocolID,
+			Type:				stageVal,
+			RecipientID:	nodes[i].ID().String(),
+			Root:				root,
+			Shards:			nodesNum,
+			Index:			uint32(i),
+			Fragment:		fragments[i],
+			Proof:			proofs[i],
+		})
+	}
+
+	//the root is accepted, but every node finds the mismatch and delivers no payload
+	timeout := time.After(10*time.Second)
+	for i := 1; i < nodesNum; i++{
+		select{
+			case d := <-deliveries:
+				if d.ProtocolID != protocolID || d.Payload != nil{
+					t.Fatalf("round %s delivered with %d bytes", d.ProtocolID, len(d.Payload))
+				}
+			case <-timeout:
+				t.Fatalf("only %d of %d nodes delivered", i-1, nodesNum-1)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package avid
+
+import(
+	"sort"
+
+	"github.com/pkg/errors"
+
+	ec "distry/erasure_codes"
+)
+
+//fragments are the shards erasure_codes codes payloads into with its cauchy matrix.
+//a shard carries the length and hash of the payload in its header, so decoding checks
+//the rebuilt payload on its own.
+
+//codec codes payloads into total fragments, any data of which rebuild the payload
+type codec struct{
+	data, total int
+	enc *ec.Encoder
+	dec *ec.Decoder
+}
+
+func newCodec(data, total int) (*codec, error){
+	if data < 1 || total < data{
+		return nil, errors.Errorf("can't code into %d fragments of which %d rebuild the payload", total, data)
+	}
+	enc, err := ec.NewEncoder(total-data, data)
+	if err != nil{
+		return nil, err
+	}
+	dec, err := ec.NewDecoder(total-data, data)
+	if err != nil{
+		return nil, err
+	}
+	return &codec{data: data, total: total, enc: enc, dec: dec}, nil
+}
+
+//encode payload into c.total fragments, fragment i is the shard of row i
+func (c *codec) encode(payload []byte) ([][]byte, error){
+	return c.enc.EncodeBytes(payload)
+}
+
+//decode rebuilds the payload from fragments, a map [ FRAGMENT_INDEX -> FRAGMENT ]
+//of at least c.data fragments
+func (c *codec) decode(fragments map[int][]byte) ([]byte, error){
+	if len(fragments) < c.data{
+		return nil, errors.Errorf("need %d fragments, got %d", c.data, len(fragments))
+	}
+
+	indexes := make([]int, 0, len(fragments))
+	for ix := range fragments{
+		if ix < 0 || ix >= c.total{
+			return nil, errors.Errorf("fragment index %d out of range", ix)
+		}
+		indexes = append(indexes, ix)
+	}
+	sort.Ints(indexes)
+	shards := make([][]byte, len(indexes))
+	for i, ix := range indexes{
+		shards[i] = fragments[ix]
+	}
+	return c.dec.DecodeBytes(shards)
+}
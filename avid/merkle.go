@@ -0,0 +1,87 @@
+package avid
+
+import(
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+//merkle tree over the fragments of a payload.
+//leaves commit to the number of fragments and their own index, so a fragment can't be
+//passed off as another one, or as a fragment of a tree of another size.
+//the leaves are padded with empty hashes to a power of two.
+
+func leafHash(shards, index int, fragment []byte) []byte{
+	h := sha256.New()
+	h.Write([]byte{0})
+	var buf [8]byte
+	binary.BigEndian.PutUint32(buf[:4], uint32(shards))
+	binary.BigEndian.PutUint32(buf[4:], uint32(index))
+	h.Write(buf[:])
+	h.Write(fragment)
+	return h.Sum(nil)
+}
+
+func nodeHash(left, right []byte) []byte{
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+//number of leaves of the tree over shards fragments
+func treeWidth(shards int) int{
+	width := 1
+	for width < shards{
+		width *= 2
+	}
+	return width
+}
+
+//merkleTree returns the root of the tree over fragments and the proof of every fragment.
+//a proof lists the siblings on the path from the leaf to the root, bottom up.
+func merkleTree(fragments [][]byte) (root []byte, proofs [][][]byte){
+	level := make([][]byte, treeWidth(len(fragments)))
+	for i := range level{
+		if i < len(fragments){
+			level[i] = leafHash(len(fragments), i, fragments[i])
+		} else{
+			level[i] = make([]byte, sha256.Size)
+		}
+	}
+
+	proofs = make([][][]byte, len(fragments))
+	for len(level) > 1{
+		for i := range fragments{
+			pos := i >> uint(len(proofs[i]))
+			proofs[i] = append(proofs[i], level[pos^1])
+		}
+
+		next := make([][]byte, len(level)/2)
+		for i := range next{
+			next[i] = nodeHash(level[2*i], level[2*i+1])
+		}
+		level = next
+	}
+	return level[0], proofs
+}
+
+//verifyProof checks that fragment is the fragment at index of the tree with root
+func verifyProof(root []byte, shards, index int, fragment []byte, proof [][]byte) bool{
+	if index < 0 || index >= shards || 1<<uint(len(proof)) != treeWidth(shards){
+		return false
+	}
+
+	hash := leafHash(shards, index, fragment)
+	pos := index
+	for _, sibling := range proof{
+		if pos%2 == 0{
+			hash = nodeHash(hash, sibling)
+		} else{
+			hash = nodeHash(sibling, hash)
+		}
+		pos /= 2
+	}
+	return bytes.Equal(hash, root)
+}
@@ -0,0 +1,77 @@
+package avid
+
+import(
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"distry/internal/retention"
+	"distry/rbc0"
+)
+
+//current round counts of the node, served by expvar under /debug/vars
+var metrics = retention.NewMetrics("avid")
+
+var errInconsistent = errors.New("rebuilt payload does not code into the dispersed fragments")
+
+//a message for a round this node doesn't know is late if its round was evicted
+func (m *Manager) isLate(protocolID string) bool{
+	initiator, stream, cnt, ok := rbc0.ParseProtocolID(protocolID)
+	if !ok{
+		return true
+	}
+	return m.lowWater.IsLate(rbc0.LowWaterKey(initiator, stream), cnt)
+}
+
+//evict accepted rounds which are too old or too many and rounds which stalled, like rbc0 does
+func (m *Manager) gc(now time.Time){
+	evictedAccepted := retention.Expired(now, m.cfg.AcceptedMaxAge, m.cfg.AcceptedMaxCount, len(m.acceptedOrder), func(i int) time.Time{
+		return m.roundInfoMap[m.acceptedOrder[i]].acceptedAt
+	})
+	for _, protocolID := range m.acceptedOrder[:evictedAccepted]{
+		m.evict(protocolID)
+	}
+	m.acceptedOrder = m.acceptedOrder[evictedAccepted:]
+
+	evictedStalled := 0
+	for protocolID, ri := range m.roundInfoMap{
+		if ri.localStage != stageAccepted && retention.Stalled(now, ri.lastActivity, m.cfg.StalledTimeout){
+			m.logger.Info("evicting stalled avid round",
+				zap.String("protocolID", protocolID),
+				zap.Uint32("localStage", ri.localStage),
+			)
+			m.evict(protocolID)
+			evictedStalled++
+		}
+	}
+
+	m.lowWater.Prune(rbc0.InEpoch(m.membershipManager.Current()))
+
+	metrics.Add("rounds_evicted_accepted", int64(evictedAccepted))
+	metrics.Add("rounds_evicted_stalled", int64(evictedStalled))
+	m.updateMetrics()
+}
+
+//only rounds this node ECHOed or which got further are remembered as evicted: that takes the
+//initiator's VAL, while anyone can send an ECHO of a round the initiator never started
+func (m *Manager) evict(protocolID string){
+	ri := m.roundInfoMap[protocolID]
+	delete(m.roundInfoMap, protocolID)
+	if ri.localStage < stageEcho{
+		return
+	}
+
+	initiator, stream, cnt, ok := rbc0.ParseProtocolID(protocolID)
+	if ok{
+		m.lowWater.Evict(rbc0.LowWaterKey(initiator, stream), cnt)
+	}
+}
+
+//publish the current round counts
+func (m *Manager) updateMetrics(){
+	accepted := len(m.acceptedOrder)
+	metrics.SetGauge("rounds_active", len(m.roundInfoMap) - accepted)
+	metrics.SetGauge("rounds_accepted", accepted)
+	metrics.SetGauge("low_water_marks", m.lowWater.Len())
+}
@@ -0,0 +1,416 @@
+package cbc
+
+import(
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"go.uber.org/zap"
+
+	"distry/internal/retention"
+	"distry/membership"
+	"distry/messages"
+	genmsg "distry/proto_gen/messages"
+	"distry/rbc0"
+)
+
+//stages of a consistent broadcast round, they double as the types of cbc messages.
+//see proto/messages.proto
+const(
+	stageSend		uint32 = 1
+	stageEcho		uint32 = 2
+	stageFinal		uint32 = 3
+	stageDelivered	uint32 = 4
+)
+
+//struct to keep info on a cbc round
+//round begins when some node SENDs a payload and ends when the payload is delivered
+type roundInfo struct{
+	//stage this node is in in regards to a cbc round.
+	//'2':ECHO, '3':FINAL (initiator only), '4':DELIVERED
+	localStage uint32
+	//membership epoch the round started in on this node, the quorum is pinned to it
+	epoch uint64
+	n, t, quorum int
+	peers map[string]struct{} //peers of the epoch, only their ECHOs count
+	initiator string
+	lastActivity time.Time
+	acceptedAt time.Time
+
+	//initiator only: the payload SENT and the ECHO signatures collected for it
+	//map [ SIGNER_ID -> SIGNATURE ]
+	payload string
+	echos map[string][]byte
+}
+
+//Omni is the part of omni.Manager cbc relies on
+type Omni interface{
+	ID() peer.ID
+	OmniPublisher(messages.Message) error
+	SendOrPublish(peer.ID, messages.Message)
+	Register(messages.Codec)
+}
+
+//Membership is the part of membership.Manager cbc relies on
+type Membership interface{
+	Current() membership.Epoch
+}
+
+//events handled by the event loop, see eventLoop
+type broadcastEvent struct{
+	payload		string
+	protocolIDC	chan<- string //protocolID of the started round is sent back on it
+}
+
+//Manager runs reiter's consistent broadcast with signed echos.
+//the initiator SENDs a payload, every peer ECHOes it with its signature over the round
+//and the payload, and once the initiator holds the signatures of a quorum it sends them
+//in a FINAL. The signatures are a certificate anyone can check, so a FINAL is delivered
+//without further messages.
+//unlike rbc0, a faulty initiator can leave some correct nodes without a delivery,
+//but no two correct nodes deliver different payloads in a round.
+type Manager struct{
+	logger	*zap.Logger
+	cfg		rbc0.Config
+	nodeID	string
+	privKey	crypto.PrivKey //signs the ECHOs
+	omniManager Omni
+	membershipManager Membership
+
+	msgC			chan messages.MsgCbc //cbc messages received from the omni network
+	broadcastC	chan broadcastEvent
+
+	//map [ PROTOCOL_ID -> roundInfo ]
+	roundInfoMap	map[string]*roundInfo
+	//PROTOCOL_IDs of the delivered rounds still in roundInfoMap, in the order they were delivered
+	acceptedOrder	[]string
+	//evicted rounds by rbc0.LowWaterKey and counter, see rbc0
+	lowWater			*retention.LowWater
+	//counter of the protocolIDs of the rounds this node starts, seeded from the clock
+	protocolCnt uint64
+
+	//this Manager sends CbcDeliveries via msgPublisher to the subscribers
+	msgPublisher	messages.Publisher
+	subscribers		messages.Fanout
+}
+
+//privKey must be the identity key of the node, peers check the ECHOs against its ID
+func NewManager(logger *zap.Logger, cfg rbc0.Config, privKey crypto.PrivKey, membershipManager Membership, omniManager Omni) *Manager{
+	if logger == nil{
+		logger = zap.NewNop()
+	}
+	cfg = cfg.WithDefaults()
+
+	pub, sub := messages.NewSubscription()
+
+	m := &Manager{
+		logger:			logger,
+		cfg:				cfg,
+		nodeID:			omniManager.ID().String(),
+		privKey:			privKey,
+		omniManager:	omniManager,
+		membershipManager:	membershipManager,
+		msgC:				make(chan messages.MsgCbc),
+		broadcastC:		make(chan broadcastEvent),
+		roundInfoMap:	make(map[string]*roundInfo),
+		acceptedOrder:	make([]string, 0),
+		lowWater:		retention.NewLowWater(cfg.EvictedWindow),
+		protocolCnt:	uint64(time.Now().UnixNano()),
+		msgPublisher:	pub,
+	}
+
+	go m.subscribers.Run(sub, func(dropped uint64){ metrics.Add("messages_dropped", int64(dropped)) })
+	go m.eventLoop()
+	m.omniManager.Register(messages.Codec{
+		Type:				genmsg.Message_CBC,
+		Marshal:			messages.MarshalCbc,
+		Unmarshal:		messages.UnmarshalCbc,
+		Handler:			m.handleOmniMsg,
+	})
+	return m
+}
+
+//the only goroutine which reads or changes protocol state
+func (m *Manager) eventLoop(){
+	gcTicker := time.NewTicker(m.cfg.GCInterval)
+	defer gcTicker.Stop()
+
+	for{
+		select{
+			case msg := <-m.msgC:
+				m.handleMsg(msg)
+			case ev := <-m.broadcastC:
+				ev.protocolIDC <- m.startRound(ev.payload)
+			case now := <-gcTicker.C:
+				m.gc(now)
+		}
+	}
+}
+
+//Handler of the codec registered with omni, pass on the received messages to the event loop
+func (m *Manager) handleOmniMsg(in messages.Message){
+	msg, ok := messages.Unwrap(in).(messages.MsgCbc)
+	if !ok{
+		return
+	}
+	m.msgC <- msg
+}
+
+//quorum of echos needed for a certificate: any two quorums of ⌈(n+t+1)/2⌉ peers
+//intersect in a correct peer, which ECHOes only one payload per round
+func quorum(n, t int) int{
+	return (n+t+2)/2
+}
+
+func (m *Manager) handleMsg(msg messages.MsgCbc){
+	ri, exists := m.roundInfoMap[msg.ProtocolID]
+	if !exists{
+		if m.isLate(msg.ProtocolID){
+			metrics.Add("late_msgs_dropped", 1)
+			return
+		}
+		if rbc0.IsOutsider(m.membershipManager.Current(), msg.ProtocolID){
+			metrics.Add("outsider_msgs_dropped", 1)
+			return
+		}
+		ri = m.instantiateRoundInfo(msg.ProtocolID)
+	}
+	if ri.localStage == stageDelivered{
+		return
+	}
+	ri.lastActivity = time.Now()
+
+	switch msg.Type{
+		case stageSend:
+			if msg.SenderID != ri.initiator{
+				m.logger.Warn("cbc discarding SEND not sent by the initiator of the round",
+					zap.String("protocolID", msg.ProtocolID),
+					zap.String("senderID", msg.SenderID),
+				)
+				return
+			}
+			//a correct node ECHOes only the first payload of a round
+			if ri.localStage < stageEcho{
+				m.echo(msg.ProtocolID, msg.Payload)
+			}
+		case stageEcho:
+			//ECHOs may come over the omni topic, only the initiator of the round collects them
+			if ri.initiator != m.nodeID{
+				return
+			}
+			m.handleEcho(msg.ProtocolID, msg.SenderID, msg.EchoSignature)
+		case stageFinal:
+			if err := m.verifyCertificate(ri, msg.ProtocolID, msg.Payload, msg.Certificate); err != nil{
+				m.logger.Warn("cbc discarding FINAL with invalid certificate",
+					zap.String("protocolID", msg.ProtocolID),
+					zap.String("senderID", msg.SenderID),
+					zap.Error(err),
+				)
+				return
+			}
+			m.deliver(msg.ProtocolID, msg.Payload, msg.Certificate)
+		default:
+			m.logger.Warn("cbc discarding msg of unknown type", zap.Uint32("type", msg.Type))
+	}
+}
+
+func (m *Manager) instantiateRoundInfo(protocolID string) *roundInfo{
+	epoch := m.membershipManager.Current()
+
+	var ri roundInfo
+	ri.epoch = epoch.Number
+	ri.n = epoch.N
+	ri.t = rbc0.MaxFaulty(epoch.N)
+	ri.quorum = quorum(ri.n, ri.t)
+	ri.peers = make(map[string]struct{})
+	for _, p := range epoch.Peers{
+		ri.peers[p.String()] = struct{}{}
+	}
+	ri.initiator, _, _, _ = rbc0.ParseProtocolID(protocolID)
+	ri.lastActivity = time.Now()
+	ri.echos = make(map[string][]byte)
+	m.roundInfoMap[protocolID] = &ri
+	m.updateMetrics()
+
+	m.logger.Debug("new cbc round",
+		zap.String("protocolID", protocolID),
+		zap.Uint64("epoch", ri.epoch),
+		zap.Int("n", ri.n),
+		zap.Int("quorum", ri.quorum),
+	)
+	return &ri
+}
+
+//sign payload as this node's ECHO of the round and send it to the initiator
+func (m *Manager) echo(protocolID, payload string){
+	ri := m.roundInfoMap[protocolID]
+	ri.localStage = stageEcho
+
+	signature, err := messages.Sign(m.privKey, messages.CbcEchoBytes(protocolID, payload))
+	if err != nil{
+		m.logger.Error("failed signing cbc ECHO", zap.String("protocolID", protocolID), zap.Error(err))
+		return
+	}
+
+	if ri.initiator == m.nodeID{
+		m.handleEcho(protocolID, m.nodeID, signature)
+		return
+	}
+	msg := messages.MsgCbc{
+		ProtocolID:		protocolID,
+		Type:				stageEcho,
+		EchoSignature:	signature,
+	}
+	//only the initiator gets the ECHO, or everyone over the omni topic if no direct stream works
+	if id, err := peer.Decode(ri.initiator); err == nil{
+		m.omniManager.SendOrPublish(id, &msg)
+	} else{
+		m.broadcast(msg)
+	}
+}
+
+//initiator collects the ECHO of signer and sends the FINAL once it holds a quorum of them
+func (m *Manager) handleEcho(protocolID, signer string, signature []byte){
+	ri := m.roundInfoMap[protocolID]
+	if ri.localStage >= stageFinal{
+		return
+	}
+	if _, exists := ri.peers[signer]; !exists{
+		return
+	}
+	if _, exists := ri.echos[signer]; exists{
+		return
+	}
+	if err := messages.VerifySignature(signer, messages.CbcEchoBytes(protocolID, ri.payload), signature); err != nil{
+		m.logger.Warn("cbc discarding ECHO with invalid signature",
+			zap.String("protocolID", protocolID),
+			zap.String("signerID", signer),
+		)
+		return
+	}
+	ri.echos[signer] = signature
+
+	if len(ri.echos) < ri.quorum{
+		return
+	}
+	certificate := make([]messages.CbcEcho, 0, len(ri.echos))
+	for signerID, signature := range ri.echos{
+		certificate = append(certificate, messages.CbcEcho{SignerID: signerID, Signature: signature})
+	}
+	ri.localStage = stageFinal
+	m.broadcast(messages.MsgCbc{
+		ProtocolID:		protocolID,
+		Type:				stageFinal,
+		Payload:			ri.payload,
+		Certificate:	certificate,
+	})
+	m.deliver(protocolID, ri.payload, certificate)
+}
+
+//a certificate is valid if it holds valid ECHO signatures of a quorum of distinct peers
+func (m *Manager) verifyCertificate(ri *roundInfo, protocolID, payload string, certificate []messages.CbcEcho) error{
+	statement := messages.CbcEchoBytes(protocolID, payload)
+	signers := make(map[string]struct{})
+	for _, echo := range certificate{
+		if _, exists := ri.peers[echo.SignerID]; !exists{
+			continue
+		}
+		if _, exists := signers[echo.SignerID]; exists{
+			continue
+		}
+		if err := messages.VerifySignature(echo.SignerID, statement, echo.Signature); err != nil{
+			return err
+		}
+		signers[echo.SignerID] = struct{}{}
+	}
+	if len(signers) < ri.quorum{
+		return errQuorum
+	}
+	return nil
+}
+
+func (m *Manager) deliver(protocolID, payload string, certificate []messages.CbcEcho){
+	ri := m.roundInfoMap[protocolID]
+	ri.localStage = stageDelivered
+	ri.acceptedAt = time.Now()
+	m.acceptedOrder = append(m.acceptedOrder, protocolID)
+	m.updateMetrics()
+	m.logger.Info("cbc round DELIVERED",
+		zap.String("protocolID", protocolID),
+		zap.String("pld", payload),
+		zap.Uint64("epoch", ri.epoch),
+	)
+
+	msg := messages.CbcDelivery{
+		SenderID:		ri.initiator,
+		ProtocolID:		protocolID,
+		Payload:			payload,
+		Certificate:	certificate,
+		AcceptedAt:		ri.acceptedAt,
+	}
+	if err := m.msgPublisher.Publish(msg); err != nil{
+		m.logger.Error("failed passing cbc message to the subscribers")
+	}
+	//cleanup round resources
+	ri.payload = ""
+	ri.echos = nil
+}
+
+func (m *Manager) broadcast(msg messages.MsgCbc){
+	if err := m.omniManager.OmniPublisher(&msg); err != nil{
+		m.logger.Error("sending cbc msg in round FAILED", zap.String("protocolID", msg.ProtocolID))
+	}
+}
+
+//SEND payload in a new round, return its protocolID
+func (m *Manager) startRound(payload string) string{
+	protocolID := m.nodeID + "_" + strconv.FormatUint(m.protocolCnt, 10)
+	m.protocolCnt++
+
+	ri := m.instantiateRoundInfo(protocolID)
+	ri.payload = payload
+	m.broadcast(messages.MsgCbc{
+		ProtocolID:	protocolID,
+		Type:			stageSend,
+		Payload:		payload,
+	})
+	m.logger.Debug("sending cbc SEND: DONE", zap.String("protocolID", protocolID))
+
+	//own messages don't come back from the omni network, ECHO own payload locally
+	m.echo(protocolID, payload)
+	return protocolID
+}
+
+
+//other parts of the node can call this to receive the CbcDeliveries of this node
+func (m *Manager) SubscribeToMessages() messages.Subscriber{
+	return m.subscribers.Subscribe(messages.DefaultSubscriptionConfig())
+}
+
+//Broadcast SENDs payload in a new round and returns its delivery once the round is delivered.
+//if ctx ends first, a *rbc0.NotAcceptedError carrying the protocolID of the round is returned.
+func (m *Manager) Broadcast(ctx context.Context, payload string) (messages.CbcDelivery, error){
+	//subscribe before the round starts, so its delivery can't be missed
+	sub := m.SubscribeToMessages()
+	defer sub.Close()
+
+	protocolIDC := make(chan string, 1)
+	m.broadcastC <- broadcastEvent{payload: payload, protocolIDC: protocolIDC}
+	protocolID := <-protocolIDC
+
+	msg, err := messages.WaitFor(ctx, sub, func(msg messages.Message) bool{
+		delivery, ok := msg.(messages.CbcDelivery)
+		return ok && delivery.ProtocolID == protocolID
+	})
+	switch{
+		case err == nil:
+			return msg.(messages.CbcDelivery), nil
+		case err == ctx.Err():
+			return messages.CbcDelivery{}, &rbc0.NotAcceptedError{ProtocolID: protocolID, Err: err}
+		default:
+			m.logger.Error("failed receiving message sent by BROADCAST", zap.Error(err))
+			return messages.CbcDelivery{}, err
+	}
+}
@@ -0,0 +1,117 @@
+package cbc
+
+import(
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"distry/internal/testnet"
+	"distry/membership"
+	"distry/messages"
+	"distry/rbc0"
+)
+
+//create n cbc Managers with real identity keys, connected through a testnet
+func setupManagers(t *testing.T, n int) ([]*Manager, []*testnet.Node){
+	t.Helper()
+
+	network := &testnet.Network{}
+	nodes := make([]*testnet.Node, 0, n)
+	privKeys := make([]crypto.PrivKey, 0, n)
+	epoch := membership.Epoch{Number: 1, N: n}
+	for i := 0; i < n; i++{
+		privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil{
+			t.Fatal(err)
+		}
+		id, err := peer.IDFromPrivateKey(privKey)
+		if err != nil{
+			t.Fatal(err)
+		}
+		nodes = append(nodes, network.AddNode(id))
+		privKeys = append(privKeys, privKey)
+		epoch.Peers = append(epoch.Peers, id)
+	}
+
+	managers := make([]*Manager, n)
+	for i, node := range nodes{
+		managers[i] = NewManager(nil, rbc0.DefaultConfig(), privKeys[i], testnet.Membership{Epoch: epoch}, node)
+	}
+	return managers, nodes
+}
+
+//read the first delivery of every manager, subscriptions block the sender until read
+func collectDeliveries(managers []*Manager) <-chan messages.CbcDelivery{
+	deliveries := make(chan messages.CbcDelivery, len(managers))
+	for _, m := range managers{
+		go func(sub messages.Subscriber){
+			msg, err := sub.Next()
+			if err == nil && msg != nil{
+				deliveries <- msg.(messages.CbcDelivery)
+			}
+		}(m.SubscribeToMessages())
+	}
+	return deliveries
+}
+
+func TestBroadcast(t *testing.T){
+	const nodesNum = 4
+	managers, _ := setupManagers(t, nodesNum)
+	deliveries := collectDeliveries(managers)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	delivery, err := managers[0].Broadcast(ctx, "consistent")
+	if err != nil{
+		t.Fatal(err)
+	}
+	if len(delivery.Certificate) < quorum(nodesNum, 1){
+		t.Fatalf("certificate holds %d echos, want at least %d", len(delivery.Certificate), quorum(nodesNum, 1))
+	}
+
+	for i := 0; i < nodesNum; i++{
+		select{
+			case d := <-deliveries:
+				if d.ProtocolID != delivery.ProtocolID || d.Payload != "consistent"{
+					t.Fatalf("round %s delivered %q", d.ProtocolID, d.Payload)
+				}
+			case <-ctx.Done():
+				t.Fatalf("only %d of %d nodes delivered", i, nodesNum)
+		}
+	}
+}
+
+func TestForgedCertificate(t *testing.T){
+	managers, nodes := setupManagers(t, 4)
+	deliveries := collectDeliveries(managers[1:2])
+
+	//node0 signs the payload on behalf of a quorum, but only its own signature is valid
+	protocolID := nodes[0].ID().String() + "_1"
+	signature, err := messages.Sign(managers[0].privKey, messages.CbcEchoBytes(protocolID, "forged"))
+	if err != nil{
+		t.Fatal(err)
+	}
+	certificate := []messages.CbcEcho{
+		{SignerID: nodes[0].ID().String(), Signature: signature},
+		{SignerID: nodes[2].ID().String(), Signature: signature},
+		{SignerID: nodes[3].ID().String(), Signature: signature},
+	}
+	nodes[1].Receive(messages.MsgCbc{
+		SenderID:		nodes[0].ID().String(),
+		ProtocolID:		protocolID,
+		Type:				stageFinal,
+		Payload:			"forged",
+		Certificate:	certificate,
+	})
+
+	select{
+		case d := <-deliveries:
+			t.Fatalf("delivered %q with a forged certificate", d.Payload)
+		case <-time.After(200*time.Millisecond):
+	}
+}
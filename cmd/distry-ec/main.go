@@ -0,0 +1,506 @@
+//distry-ec codes files into erasure coded shards, any n of which suffice to decode the file,
+//see the erasure_codes package.
+//
+//	distry-ec encode [-k 3] [-n 4] [-mode cauchy] [-o dir] [-f] file
+//	distry-ec decode [-o file] [-f] [-correct] shard...
+//	distry-ec verify shard...
+//	distry-ec repair [-rows 0,5] [-prefix path] [-f] shard...
+//
+//flags may also follow the arguments. The shards of file are named file.0.shard to file.<n+k-1>.shard.
+//Exit code is 0 on success, 1 on failure and 2 on wrong usage.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	ec "distry/erasure_codes"
+)
+
+//a usageError exits with code 2 and the usage of the command, after its message if there is one
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+var errUsage = usageError{}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+//file.3.shard is the shard of row 3 of file
+var shardName = regexp.MustCompile(`^(.*)\.(\d+)\.shard$`)
+
+func shardPath(prefix string, row int) string {
+	return fmt.Sprintf("%s.%d.shard", prefix, row)
+}
+
+type command struct {
+	usage string
+	run func(args []string) error
+}
+
+var commands = map[string]command{
+	"encode": {"encode [-k 3] [-n 4] [-mode cauchy|systematic] [-o dir] [-f] file", encode},
+	"decode": {"decode [-o file] [-f] [-correct] shard...", decode},
+	"verify": {"verify shard...", verify},
+	"repair": {"repair [-rows 0,5] [-prefix path] [-f] shard...", repair},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+//run the command of args and return the exit code
+func run(args []string) int {
+	if len(args) < 1 {
+		usage()
+		return 2
+	}
+	cmd, exists := commands[args[0]]
+	if !exists {
+		usage()
+		return 2
+	}
+
+	if err := cmd.run(args[1:]); err != nil {
+		if usageErr, ok := err.(usageError); ok {
+			if usageErr.msg != "" {
+				fmt.Fprintf(os.Stderr, "distry-ec %s: %s\n", args[0], usageErr.msg)
+			}
+			fmt.Fprintf(os.Stderr, "usage: distry-ec %s\n", cmd.usage)
+			return 2
+		}
+		fmt.Fprintf(os.Stderr, "distry-ec %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, "usage:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "\tdistry-ec %s\n", commands[name].usage)
+	}
+}
+
+//parse the flags of a command and return its arguments. Flags may come before, between and after
+//the arguments, everything after -- is an argument.
+func parseFlags(fs *flag.FlagSet, args []string, minArgs int) ([]string, error) {
+	fs.SetOutput(os.Stderr)
+	var positional []string
+	for len(args) > 0 {
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		rest := fs.Args()
+		if len(rest) < len(args) && args[len(args)-len(rest)-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		i := 0
+		for i < len(rest) && (rest[i] == "-" || !strings.HasPrefix(rest[i], "-")) {
+			i++
+		}
+		positional = append(positional, rest[:i]...)
+		args = rest[i:]
+	}
+	if len(positional) < minArgs {
+		return nil, errUsage
+	}
+	return positional, nil
+}
+
+//------------------------------------
+
+func encode(args []string) error {
+	fs := flag.NewFlagSet("encode", flag.ContinueOnError)
+	k := fs.Int("k", 3, "number of shards which may be lost")
+	n := fs.Int("n", 4, "number of shards needed to decode")
+	modeName := fs.String("mode", ec.ModeCauchy.String(), "coding matrix, cauchy or systematic (the first n shards hold the file as it is)")
+	dir := fs.String("o", "", "directory of the shards, the directory of the file by default")
+	force := fs.Bool("f", false, "overwrite the shards if they exist")
+	quiet := fs.Bool("q", false, "don't report progress")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil || len(args) != 1 {
+		return errUsage
+	}
+
+	var mode ec.Mode
+	switch *modeName {
+		case ec.ModeCauchy.String():
+			mode = ec.ModeCauchy
+		case ec.ModeSystematic.String():
+			mode = ec.ModeSystematic
+		default:
+			return usageErrorf("unknown mode %s", *modeName)
+	}
+	enc, err := ec.NewModeEncoder(*k, *n, mode)
+	if err != nil {
+		return err
+	}
+
+	path := args[0]
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	stat, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	if *dir == "" {
+		*dir = filepath.Dir(path)
+	}
+	prefix := filepath.Join(*dir, filepath.Base(path))
+	paths := make([]string, enc.Shards())
+	for i := range paths {
+		paths[i] = shardPath(prefix, i)
+	}
+
+	p := newProgress(progressOut(*quiet), "encoding", uint64(enc.Shards())*enc.ShardSize(uint64(stat.Size())))
+	err = writeFiles(paths, *force, func(writers []io.Writer) error {
+		for i := range writers {
+			writers[i] = p.wrap(writers[i])
+		}
+		return enc.Encode(in, writers)
+	})
+	p.finish()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s coded into %s to %s, any %d of which decode it\n", path, paths[0], paths[len(paths)-1], *n)
+	return nil
+}
+
+func decode(args []string) error {
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	out := fs.String("o", "", "decoded file, by default the name of the shards without .<row>.shard")
+	force := fs.Bool("f", false, "overwrite the decoded file if it exists")
+	correct := fs.Bool("correct", false, "use every shard to find and correct wrong shards, slower")
+	quiet := fs.Bool("q", false, "don't report progress")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		match := shardName.FindStringSubmatch(args[0])
+		if match == nil {
+			return usageErrorf("can't name the decoded file after %s, use -o", args[0])
+		}
+		*out = match[1]
+	}
+	header, err := commonHeader(args)
+	if err != nil {
+		return err
+	}
+	newDecoder := ec.NewDecoder
+	if *correct {
+		newDecoder = ec.NewCorrectingDecoder
+	}
+	dec, err := newDecoder(header.K, header.N)
+	if err != nil {
+		return err
+	}
+
+	var report ec.Report
+	p := newProgress(progressOut(*quiet), "decoding", header.Length)
+	err = withShards(args, func(shards []io.Reader) error {
+		return writeFiles([]string{*out}, *force, func(writers []io.Writer) error {
+			report, err = dec.DecodeReport(shards, p.wrap(writers[0]))
+			return err
+		})
+	})
+	p.finish()
+	printReport(args, report)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("decoded %s\n", *out)
+	return nil
+}
+
+//verify decodes the shards without writing the data. It uses every shard, so it finds the shards
+//which are corrupt, of other data, or wrong as long as most of them are right.
+func verify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	quiet := fs.Bool("q", false, "don't report progress")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	header, err := commonHeader(args)
+	if err != nil {
+		return err
+	}
+	dec, err := ec.NewCorrectingDecoder(header.K, header.N)
+	if err != nil {
+		return err
+	}
+
+	var report ec.Report
+	p := newProgress(progressOut(*quiet), "verifying", header.Length)
+	err = withShards(args, func(shards []io.Reader) error {
+		report, err = dec.DecodeReport(shards, p.wrap(ioutil.Discard))
+		return err
+	})
+	p.finish()
+	printReport(args, report)
+	if err != nil {
+		return err
+	}
+
+	if bad := len(report.Bad()); bad > 0 {
+		return errors.Errorf("%d of %d shards are bad, the data can still be decoded", bad, len(args))
+	}
+	fmt.Printf("%d shards ok, %s coding (k=%d n=%d) of %d bytes\n", len(args), header.Mode, header.K, header.N, header.Length)
+	return nil
+}
+
+func repair(args []string) error {
+	fs := flag.NewFlagSet("repair", flag.ContinueOnError)
+	rowList := fs.String("rows", "", "comma separated rows of the shards to repair, by default those of which no valid shard is given")
+	prefix := fs.String("prefix", "", "repaired shards are named <prefix>.<row>.shard, by default the prefix of the shards given")
+	force := fs.Bool("f", false, "overwrite repaired shards if they exist")
+	quiet := fs.Bool("q", false, "don't report progress")
+	args, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	header, err := commonHeader(args)
+	if err != nil {
+		return err
+	}
+	m, err := ec.NewManager(header.K, header.N)
+	if err != nil {
+		return err
+	}
+
+	var missing []int
+	if *rowList != "" {
+		for _, s := range strings.Split(*rowList, ",") {
+			row, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return usageErrorf("row %s", s)
+			}
+			missing = append(missing, row)
+		}
+	} else {
+		present := make(map[int]bool)
+		for _, path := range args {
+			if h, err := readHeader(path); err == nil && h.K == header.K && h.N == header.N {
+				present[h.Row] = true
+			}
+		}
+		for row := 0; row < m.Shards(); row++ {
+			if !present[row] {
+				missing = append(missing, row)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		fmt.Println("no shard is missing")
+		return nil
+	}
+
+	if *prefix == "" {
+		match := shardName.FindStringSubmatch(args[0])
+		if match == nil {
+			return usageErrorf("can't name the repaired shards after %s, use -prefix", args[0])
+		}
+		*prefix = match[1]
+	}
+	paths := make([]string, len(missing))
+	for i, row := range missing {
+		paths[i] = shardPath(*prefix, row)
+		for _, in := range args {
+			if filepath.Clean(in) == filepath.Clean(paths[i]) {
+				return usageErrorf("%s is read to repair row %d, move it away first", in, row)
+			}
+		}
+	}
+
+	var report ec.Report
+	p := newProgress(progressOut(*quiet), "repairing", uint64(len(missing))*header.ShardSize())
+	err = withShards(args, func(shards []io.Reader) error {
+		return writeFiles(paths, *force, func(writers []io.Writer) error {
+			for i := range writers {
+				writers[i] = p.wrap(writers[i])
+			}
+			report, err = m.Repair(shards, missing, writers)
+			return err
+		})
+	})
+	p.finish()
+	printReport(args, report)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("repaired %s\n", strings.Join(paths, ", "))
+	return nil
+}
+
+//------------------------------------
+
+func progressOut(quiet bool) io.Writer {
+	if quiet {
+		return nil
+	}
+	return os.Stderr
+}
+
+func readHeader(path string) (ec.Header, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ec.Header{}, err
+	}
+	defer f.Close()
+	return ec.ReadHeader(bufio.NewReader(f))
+}
+
+//find the header most of the shards share, the decoder skips the others
+func commonHeader(paths []string) (ec.Header, error) {
+	var headers []ec.Header
+	counts := make(map[ec.Header]int)
+	for _, path := range paths {
+		h, err := readHeader(path)
+		if err != nil {
+			continue
+		}
+		h.Row = 0
+		if counts[h] == 0 {
+			headers = append(headers, h)
+		}
+		counts[h]++
+	}
+	if len(headers) == 0 {
+		return ec.Header{}, errors.Wrap(ec.ErrTooFewShards, "no valid shards")
+	}
+
+	common := headers[0]
+	for _, h := range headers[1:] {
+		if counts[h] > counts[common] {
+			common = h
+		}
+	}
+	return common, nil
+}
+
+//open the shards for f, reading them through buffers
+func withShards(paths []string, f func(shards []io.Reader) error) error {
+	shards := make([]io.Reader, 0, len(paths))
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		shards = append(shards, bufio.NewReader(file))
+	}
+	return f(shards)
+}
+
+//create the files for f to write through buffers. Existing files are only overwritten if force
+//is set, and only once f succeeded: each is written to a temporary file next to it, which then
+//replaces it. If f fails, the files it wrote are removed and the existing ones stay as they were.
+func writeFiles(paths []string, force bool, f func(writers []io.Writer) error) (err error) {
+	files := make([]*os.File, 0, len(paths))
+	defer func() {
+		for _, file := range files {
+			if closeErr := file.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+		for i, file := range files {
+			if err == nil && file.Name() != paths[i] {
+				err = os.Rename(file.Name(), paths[i])
+			}
+			if err != nil {
+				os.Remove(file.Name())
+			}
+		}
+	}()
+
+	bufs := make([]*bufio.Writer, len(paths))
+	writers := make([]io.Writer, len(paths))
+	for i, path := range paths {
+		file, err := createFile(path, force)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+		bufs[i] = bufio.NewWriterSize(file, 1 << 16)
+		writers[i] = bufs[i]
+	}
+
+	if err := f(writers); err != nil {
+		return err
+	}
+	for _, buf := range bufs {
+		if err := buf.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//create the file at path, or a temporary file next to it if it exists and force is set
+func createFile(path string, force bool) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY | os.O_CREATE | os.O_EXCL, 0644)
+	if !os.IsExist(err) {
+		return file, err
+	}
+	if !force {
+		return nil, errors.Errorf("%s exists, use -f to overwrite it", path)
+	}
+
+	file, err = ioutil.TempFile(filepath.Dir(path), "." + filepath.Base(path) + ".*")
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Chmod(0644); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	return file, nil
+}
+
+func printReport(paths []string, report ec.Report) {
+	for i, path := range paths {
+		if err, skipped := report.Skipped[i]; skipped {
+			fmt.Fprintf(os.Stderr, "%s: skipped: %v\n", path, err)
+		}
+		if blocks := report.CorruptBlocks[i]; blocks > 0 {
+			fmt.Fprintf(os.Stderr, "%s: %d corrupt blocks\n", path, blocks)
+		}
+		if wrong := report.Corrected[i]; wrong > 0 {
+			fmt.Fprintf(os.Stderr, "%s: %d wrong bytes corrected\n", path, wrong)
+		}
+	}
+}
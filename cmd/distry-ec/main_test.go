@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCommands(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	data := make([]byte, 100000)
+	rand.New(rand.NewSource(1)).Read(data)
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	shard := func(row int) string {
+		return shardPath(path, row)
+	}
+	expectExit := func(code int, args ...string) {
+		t.Helper()
+		if exit := run(append(args, "-q")); exit != code {
+			t.Fatalf("distry-ec %v exited with %d, expected %d", args, exit, code)
+		}
+	}
+	expectFile := func(path string, expected []byte) {
+		t.Helper()
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(content, expected) {
+			t.Fatalf("%s holds %d unexpected bytes", path, len(content))
+		}
+	}
+
+	//usage
+	expectExit(2, "unknown")
+	expectExit(2, "encode")
+	expectExit(2, "encode", "-mode", "unknown", path)
+	expectExit(2, "decode", "-unknown", shard(0))
+
+	//the shards are named after the file, any n=4 of them decode it
+	expectExit(0, "encode", "-k", "3", path)
+	for row := 0; row < 7; row++ {
+		if _, err := os.Stat(shard(row)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectExit(1, "encode", path) //the shards exist
+	shard0, err := ioutil.ReadFile(shard(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(shard(0), []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectExit(0, "encode", "-f", "-k", "3", path)
+	expectFile(shard(0), shard0)
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	expectExit(0, "decode", shard(1), shard(3), shard(5), shard(6))
+	expectFile(path, data)
+	//flags may follow the shards
+	out := filepath.Join(dir, "out")
+	expectExit(0, "decode", shard(0), shard(2), shard(4), shard(6), "-o", out)
+	expectFile(out, data)
+
+	//a failed decode removes its output, but not an existing file it was forced to overwrite
+	expectExit(1, "decode", shard(0), shard(1), shard(2), "-o", filepath.Join(dir, "failed"))
+	if _, err := os.Stat(filepath.Join(dir, "failed")); !os.IsNotExist(err) {
+		t.Fatalf("the output of a failed decode exists: %v", err)
+	}
+	expectExit(1, "decode", shard(0), shard(1), "-o", out)
+	expectExit(1, "decode", "-f", shard(0), shard(1), "-o", out)
+	expectFile(out, data)
+	expectExit(0, "decode", "-f", shard(3), shard(4), shard(5), shard(6), "-o", out)
+	expectFile(out, data)
+
+	//repair rewrites lost shards as they were
+	original, err := ioutil.ReadFile(shard(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(shard(2)); err != nil {
+		t.Fatal(err)
+	}
+	expectExit(2, "repair", "-rows", "0", shard(0), shard(1), shard(3), shard(4)) //reads the shard it writes
+	expectExit(0, "repair", shard(0), shard(1), shard(3), shard(4), shard(5), shard(6))
+	expectFile(shard(2), original)
+
+	//verify fails once a shard is corrupt
+	shards := []string{shard(0), shard(1), shard(2), shard(3), shard(4), shard(5), shard(6)}
+	expectExit(0, append([]string{"verify"}, shards...)...)
+	corrupt := append([]byte(nil), original...)
+	corrupt[len(corrupt)/2] ^= 1
+	if err := ioutil.WriteFile(shard(2), corrupt, 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectExit(1, append([]string{"verify"}, shards...)...)
+	//and the data still decodes
+	expectExit(0, append([]string{"decode", "-f", "-correct", "-o", out}, shards...)...)
+	expectFile(out, data)
+}
+
+func TestParseFlags(t *testing.T) {
+	for _, c := range []struct {
+		args []string
+		positional []string
+		out string
+	}{
+		{[]string{"-o", "x", "a", "b"}, []string{"a", "b"}, "x"},
+		{[]string{"a", "-o", "x", "b"}, []string{"a", "b"}, "x"},
+		{[]string{"a", "b", "-o", "x"}, []string{"a", "b"}, "x"},
+		{[]string{"a", "--", "-o", "x"}, []string{"a", "-o", "x"}, ""},
+		{[]string{"-", "a"}, []string{"-", "a"}, ""},
+	} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		out := fs.String("o", "", "")
+		positional, err := parseFlags(fs, c.args, 1)
+		if err != nil {
+			t.Fatalf("%v: %v", c.args, err)
+		}
+		if *out != c.out || len(positional) != len(c.positional) {
+			t.Fatalf("%v: parsed -o %q and %v", c.args, *out, positional)
+		}
+		for i := range positional {
+			if positional[i] != c.positional[i] {
+				t.Fatalf("%v: parsed %v", c.args, positional)
+			}
+		}
+	}
+
+	if _, err := parseFlags(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--"}, 1); err != errUsage {
+		t.Fatalf("expected errUsage, got %v", err)
+	}
+}
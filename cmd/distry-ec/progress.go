@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+//progress counts the bytes written through it and reports the percentage of total done to out,
+//every time it grows by a percent
+type progress struct {
+	out io.Writer
+	label string
+	total, done uint64
+	percent int
+}
+
+func newProgress(out io.Writer, label string, total uint64) *progress {
+	p := &progress{out: out, label: label, total: total, percent: -1}
+	p.report()
+	return p
+}
+
+func (p *progress) Write(b []byte) (int, error) {
+	p.done += uint64(len(b))
+	p.report()
+	return len(b), nil
+}
+
+//wrap w, so every byte written to w is counted
+func (p *progress) wrap(w io.Writer) io.Writer {
+	return io.MultiWriter(w, p)
+}
+
+func (p *progress) report() {
+	if p.out == nil {
+		return
+	}
+	percent := 100
+	if p.total > 0 && p.done < p.total {
+		percent = int(p.done * 100 / p.total)
+	}
+	if percent == p.percent {
+		return
+	}
+	p.percent = percent
+	fmt.Fprintf(p.out, "\r%s %3d%%", p.label, percent)
+}
+
+//end the line of the report
+func (p *progress) finish() {
+	if p.out != nil {
+		fmt.Fprintln(p.out)
+	}
+}
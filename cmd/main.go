@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	_"expvar" //serves metrics under /debug/vars
 	"flag"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -16,16 +18,22 @@ import (
 	"google.golang.org/grpc"
 
 	"distry/api"
+	"distry/dkg"
 	apigen "distry/proto_gen/api"
 	"distry/node"
+	"distry/rbc0"
 )
 
 type cfg struct{
 	NodePort			uint16
 	APIPort			uint16
+	MetricsPort		uint16
 	BootstrapOnly	bool
+	HashCoin			bool
 	PrivKey			string
 	BootstrapNodes	[]multiaddr.Multiaddr
+	Rbc0				rbc0.Config
+	Dkg				dkg.Config
 }
 
 
@@ -42,7 +50,7 @@ func main() {
 		panic(err)
 	}
 
-	n := node.NewNode(logger, cfg.BootstrapOnly)
+	n := node.NewNode(logger, cfg.BootstrapOnly, cfg.HashCoin, cfg.Rbc0, cfg.Dkg)
 	if err := n.Start(ctx, cfg.NodePort, cfg.PrivKey); err != nil {
 		panic(err)
 	}
@@ -85,6 +93,17 @@ func main() {
 		}()
 	}
 
+	if cfg.MetricsPort != 0 {
+		metricsListenerAddr := fmt.Sprintf("0.0.0.0:%d", cfg.MetricsPort)
+		logger.Info("starting metrics server", zap.String("address", metricsListenerAddr))
+
+		go func() {
+			if err := http.ListenAndServe(metricsListenerAddr, nil); err != nil {
+				logger.Error("failed serving metrics", zap.Error(err))
+			}
+		}()
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGKILL, syscall.SIGTERM)
 	<-sigChan
@@ -94,8 +113,19 @@ func parseArgs() (cfg, error) {
 	nodePort := flag.Uint("port", 0, "node port")
 	bootstrapOnly := flag.Bool("bootstrap-only", false, "whether the node should only serve as a bootstrap node (e.g. it will not respond to send message requests)")
 	apiPort := flag.Uint("api.port", 0, "api port")
+	metricsPort := flag.Uint("metrics.port", 0, "port on which metrics are served under /debug/vars, 0 disables it")
 	bootstrapNodes := flag.String("bootstrap.addrs", "", "comma separated list of bootstrap node addresses")
 	privKey := flag.String("privkey", "", "filepath from which node should read private key")
+	rbc0Cfg := rbc0.DefaultConfig()
+	flag.DurationVar(&rbc0Cfg.AcceptedMaxAge, "rbc0.accepted-max-age", rbc0Cfg.AcceptedMaxAge, "how long accepted rbc0 rounds are remembered")
+	flag.IntVar(&rbc0Cfg.AcceptedMaxCount, "rbc0.accepted-max-count", rbc0Cfg.AcceptedMaxCount, "max number of accepted rbc0 rounds remembered")
+	flag.DurationVar(&rbc0Cfg.StalledTimeout, "rbc0.stalled-timeout", rbc0Cfg.StalledTimeout, "how long a rbc0 round may receive no messages before it is dropped")
+	flag.IntVar(&rbc0Cfg.EvictedWindow, "rbc0.evicted-window", rbc0Cfg.EvictedWindow, "number of the latest evicted rounds of each initiator remembered one by one, the older ones are covered by a low-water mark")
+	flag.IntVar(&rbc0Cfg.DeliveryLogSize, "rbc0.delivery-log-size", rbc0Cfg.DeliveryLogSize, "number of the latest rbc0 deliveries kept for replay to new subscribers")
+	flag.IntVar(&rbc0Cfg.DeliveryBuffer, "rbc0.delivery-buffer", rbc0Cfg.DeliveryBuffer, "deliveries (and acs outputs) buffered for a SubscribeDeliveries (SubscribeEpochOutputs) client before the oldest are dropped")
+	hashCoin := flag.Bool("aba.hash-coin", false, "INSECURE, only for tests: without coin keys, run aba on a coin anyone can predict instead of turning aba and acs off")
+	dkgCfg := dkg.DefaultConfig()
+	flag.DurationVar(&dkgCfg.PhaseTimeout, "dkg.phase-timeout", dkgCfg.PhaseTimeout, "how long each phase of a dkg session lasts, long enough for a broadcast to reach every node")
 	flag.Parse()
 
 	if *nodePort == 0 {
@@ -117,8 +147,12 @@ func parseArgs() (cfg, error) {
 	return cfg{
 		NodePort:			uint16(*nodePort),
 		APIPort:				uint16(*apiPort),
+		MetricsPort:		uint16(*metricsPort),
 		BootstrapOnly:		*bootstrapOnly,
+		HashCoin:			*hashCoin,
 		BootstrapNodes:	bootstrapNodeAddrs,
 		PrivKey:				*privKey,
+		Rbc0:					rbc0Cfg,
+		Dkg:					dkgCfg,
 	}, nil
 }
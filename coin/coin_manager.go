@@ -0,0 +1,295 @@
+package coin
+
+import(
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+
+	"filippo.io/edwards25519"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"distry/messages"
+	genmsg "distry/proto_gen/messages"
+	"distry/rbc0"
+	"distry/ssecret_sharing"
+)
+
+//struct to keep info on a coin
+type coinInfo struct{
+	point		*edwards25519.Point //hashToPoint of the coin name
+	//map [ SHARE_INDEX -> share ]
+	//verified coin shares, including this node's once it tossed
+	shares	map[uint32]*edwards25519.Point
+	tossed	bool //this node published its share
+	value		[]byte //nil until t+1 shares are combined
+	//TossBytes calls waiting for the value, buffered so the event loop never blocks on them
+	waiters	[]chan<- []byte
+	lastActivity	time.Time
+	doneAt			time.Time
+}
+
+//Omni is the part of omni.Manager coin relies on
+type Omni interface{
+	ID() peer.ID
+	OmniPublisher(messages.Message) error
+	Register(messages.Codec)
+}
+
+//events handled by the event loop, see eventLoop
+type share struct{
+	name		string
+	index		uint32
+	share		*edwards25519.Point
+}
+type tossEvent struct{
+	name		string
+	valueC	chan<- []byte
+}
+
+//Manager tosses the threshold coin of cachin, kursawe & shoup
+//(random oracles in constantinople, 2000) over the edwards25519 group.
+//the coin secret key x is shared among the nodes, node i holding x_i.
+//the coin of name N is hash(x*H(N)), where H hashes N onto the group:
+//	- to toss, a node publishes its coin share x_i*H(N) with a proof that it has
+//	  the same discrete log as its verification key x_i*B
+//	- any t+1 valid shares interpolate in the exponent into x*H(N)
+//t nodes together don't know x*H(N), so the coin can't be predicted before a correct node tosses it.
+type Manager struct{
+	logger	*zap.Logger
+	cfg		rbc0.Config
+	nodeID	string
+	keys		*Keys
+	omniManager Omni
+
+	shareC	chan share //verified coin shares received from the omni network
+	tossC		chan tossEvent
+
+	//map [ COIN_NAME -> coinInfo ]
+	coinInfoMap	map[string]*coinInfo
+	//names of the coins with a value still in coinInfoMap, in the order they got it
+	doneOrder	[]string
+}
+
+//keys must hold a share of the node
+func NewManager(logger *zap.Logger, cfg rbc0.Config, keys *Keys, omniManager Omni) (*Manager, error){
+	if logger == nil{
+		logger = zap.NewNop()
+	}
+	cfg = cfg.WithDefaults()
+	nodeID := omniManager.ID().String()
+	if err := keys.Check(nodeID); err != nil{
+		return nil, errors.Wrap(err, "checking coin keys")
+	}
+
+	m := &Manager{
+		logger:			logger,
+		cfg:				cfg,
+		nodeID:			nodeID,
+		keys:				keys,
+		omniManager:	omniManager,
+		shareC:			make(chan share),
+		tossC:			make(chan tossEvent),
+		coinInfoMap:	make(map[string]*coinInfo),
+		doneOrder:		make([]string, 0),
+	}
+
+	go m.eventLoop()
+	m.omniManager.Register(messages.Codec{
+		Type:				genmsg.Message_COIN,
+		Marshal:			messages.MarshalCoin,
+		Unmarshal:		messages.UnmarshalCoin,
+		Handler:			m.handleOmniMsg,
+	})
+	return m, nil
+}
+
+//the only goroutine which reads or changes protocol state
+func (m *Manager) eventLoop(){
+	gcTicker := time.NewTicker(m.cfg.GCInterval)
+	defer gcTicker.Stop()
+
+	for{
+		select{
+			case sh := <-m.shareC:
+				m.handleShare(sh)
+			case ev := <-m.tossC:
+				m.toss(ev)
+			case now := <-gcTicker.C:
+				m.gc(now)
+		}
+	}
+}
+
+//verify received shares before they reach the event loop, verification is the costly part
+func (m *Manager) handleOmniMsg(in messages.Message){
+	msg, ok := messages.Unwrap(in).(messages.MsgCoin)
+	if !ok{
+		return
+	}
+	sh, err := m.verify(msg)
+	if err != nil{
+		metrics.Add("shares_invalid", 1)
+		m.logger.Warn("coin discarding invalid share",
+			zap.String("sender", msg.SenderID),
+			zap.String("name", msg.Name),
+			zap.Error(err),
+		)
+		return
+	}
+	m.shareC <- sh
+}
+
+func (m *Manager) verify(msg messages.MsgCoin) (share, error){
+	holder, exists := m.keys.Holders[msg.SenderID]
+	if !exists{
+		return share{}, errors.New("sender holds no coin share")
+	}
+	point := hashToPoint(msg.Name)
+	sh, proof, err := decodeShare(msg.Share, msg.Challenge, msg.Response)
+	if err != nil{
+		return share{}, err
+	}
+	if !verifyDleq(proof, point, holder.VerificationKey, sh){
+		return share{}, errors.New("proof does not verify")
+	}
+	return share{name: msg.Name, index: holder.Index, share: sh}, nil
+}
+
+func (m *Manager) coin(name string) *coinInfo{
+	ci, exists := m.coinInfoMap[name]
+	if !exists{
+		ci = &coinInfo{
+			point:	hashToPoint(name),
+			shares:	make(map[uint32]*edwards25519.Point),
+			lastActivity:	time.Now(),
+		}
+		m.coinInfoMap[name] = ci
+		m.updateMetrics()
+	}
+	return ci
+}
+
+func (m *Manager) handleShare(sh share){
+	ci := m.coin(sh.name)
+	if ci.value != nil{
+		return
+	}
+	ci.lastActivity = time.Now()
+	ci.shares[sh.index] = sh.share
+	m.combine(sh.name)
+}
+
+//publish this node's share of the coin, unless it did already
+func (m *Manager) toss(ev tossEvent){
+	ci := m.coin(ev.name)
+	if ci.value != nil{
+		ev.valueC <- ci.value
+		return
+	}
+	ci.waiters = append(ci.waiters, ev.valueC)
+	ci.lastActivity = time.Now()
+	if ci.tossed{
+		return
+	}
+	ci.tossed = true
+
+	holder := m.keys.Holders[m.nodeID]
+	sh := new(edwards25519.Point).ScalarMult(m.keys.Share, ci.point)
+	proof, err := proveDleq(m.keys.Share, ci.point, holder.VerificationKey, sh, rand.Reader)
+	if err != nil{
+		//the coin is tossed again by the next TossBytes
+		ci.tossed = false
+		m.logger.Error("failed proving coin share", zap.String("name", ev.name), zap.Error(err))
+		return
+	}
+
+	msg := messages.MsgCoin{
+		Name:			ev.name,
+		Share:		sh.Bytes(),
+		Challenge:	proof.challenge.Bytes(),
+		Response:	proof.response.Bytes(),
+	}
+	if err := m.omniManager.OmniPublisher(&msg); err != nil{
+		m.logger.Error("sending coin share FAILED", zap.String("name", ev.name))
+	}
+
+	ci.shares[m.keys.Index] = sh
+	m.combine(ev.name)
+}
+
+//interpolate t+1 shares in the exponent into x*H(N) and hash it into the value of the coin.
+//the point is multiplied by the cofactor first: the small order components a forged share
+//might carry vanish, so every choice of t+1 valid shares gives the same value.
+func (m *Manager) combine(name string){
+	ci := m.coinInfoMap[name]
+	if ci.value != nil || len(ci.shares) < m.keys.T+1{
+		return
+	}
+
+	indexes := make([]uint32, 0, len(ci.shares))
+	for index := range ci.shares{
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool{ return indexes[i] < indexes[j] })
+	indexes = indexes[:m.keys.T+1]
+
+	coefs := make([]*edwards25519.Scalar, len(indexes))
+	points := make([]*edwards25519.Point, len(indexes))
+	for i, index := range indexes{
+		coef, err := ssecret_sharing.LagrangeAtZero(indexes, index)
+		if err != nil{
+			m.logger.Error("failed combining coin shares", zap.String("name", name), zap.Error(err))
+			return
+		}
+		coefs[i] = coef
+		points[i] = ci.shares[index]
+	}
+	combined := new(edwards25519.Point).VarTimeMultiScalarMult(coefs, points)
+	combined.MultByCofactor(combined)
+
+	h := sha256.New()
+	h.Write([]byte(domainValue))
+	h.Write([]byte(name))
+	h.Write(combined.Bytes())
+	ci.value = h.Sum(nil)
+	ci.shares = nil
+	ci.doneAt = time.Now()
+	m.doneOrder = append(m.doneOrder, name)
+	m.updateMetrics()
+	m.logger.Debug("coin combined", zap.String("name", name))
+
+	for _, waiter := range ci.waiters{
+		waiter <- ci.value
+	}
+	ci.waiters = nil
+}
+
+//TossBytes tosses the coin of the given name and returns its 32 byte value.
+//every node tossing the coin of the same name gets the same value once t+1 nodes toss it.
+//if ctx ends first, ctx.Err() is returned and this node's share stays published.
+func (m *Manager) TossBytes(ctx context.Context, name string) ([]byte, error){
+	valueC := make(chan []byte, 1)
+	m.tossC <- tossEvent{name: name, valueC: valueC}
+
+	select{
+		case value := <-valueC:
+			return value, nil
+		case <-ctx.Done():
+			m.logger.Debug("coin not combined before deadline", zap.String("name", name))
+			return nil, ctx.Err()
+	}
+}
+
+//Toss is the common coin of an aba session round, it makes Manager an aba.Coin
+func (m *Manager) Toss(ctx context.Context, sessionID string, round uint32) (bool, error){
+	value, err := m.TossBytes(ctx, fmt.Sprintf("aba_%s_%d", sessionID, round))
+	if err != nil{
+		return false, err
+	}
+	return value[0] & 1 == 1, nil
+}
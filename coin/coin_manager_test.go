@@ -0,0 +1,143 @@
+package coin
+
+import(
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"distry/internal/testnet"
+	"distry/messages"
+	"distry/rbc0"
+	"distry/ssecret_sharing"
+)
+
+//deal coin keys to n nodes with threshold t and create Managers for the first running of them
+func setupManagers(t *testing.T, n, threshold, running int) ([]*Manager, []*testnet.Node){
+	t.Helper()
+
+	peerIDs := make([]string, n)
+	for i := range peerIDs{
+		peerIDs[i] = peer.ID(fmt.Sprintf("node%d", i)).String()
+	}
+	keys, err := Deal(peerIDs, threshold, rand.Reader)
+	if err != nil{
+		t.Fatal(err)
+	}
+
+	network := &testnet.Network{}
+	nodes := make([]*testnet.Node, n)
+	for i := range nodes{
+		nodes[i] = network.AddNode(peer.ID(fmt.Sprintf("node%d", i)))
+	}
+	managers := make([]*Manager, running)
+	for i := range managers{
+		managers[i], err = NewManager(nil, rbc0.DefaultConfig(), keys[peerIDs[i]], nodes[i])
+		if err != nil{
+			t.Fatal(err)
+		}
+	}
+	return managers, nodes
+}
+
+//toss the coin on all managers at once and check they all get the same value
+func toss(t *testing.T, managers []*Manager, name string) []byte{
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	values := make([][]byte, len(managers))
+	errs := make(chan error, len(managers))
+	wg := new(sync.WaitGroup)
+	for i, m := range managers{
+		wg.Add(1)
+		go func(i int, m *Manager){
+			defer wg.Done()
+			value, err := m.TossBytes(ctx, name)
+			if err != nil{
+				errs <- err
+				return
+			}
+			values[i] = value
+		}(i, m)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs{
+		t.Fatal(err)
+	}
+
+	for i := range managers{
+		if !bytes.Equal(values[i], values[0]){
+			t.Fatalf("coin %s: node %d got %x, node 0 got %x", name, i, values[i], values[0])
+		}
+	}
+	return values[0]
+}
+
+func TestToss(t *testing.T){
+	managers, _ := setupManagers(t, 4, 1, 4)
+
+	seen := make(map[string]bool)
+	for c := 0; c < 10; c++{
+		value := toss(t, managers, fmt.Sprintf("coin%d", c))
+		if seen[string(value)]{
+			t.Fatalf("coin%d repeats the value of another coin", c)
+		}
+		seen[string(value)] = true
+	}
+}
+
+func TestTossThreshold(t *testing.T){
+	//t+1=3 of the n=7 nodes toss
+	managers, _ := setupManagers(t, 7, 2, 3)
+	toss(t, managers, "enough")
+
+	//t nodes can't toss alone
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	for _, m := range managers[:2]{
+		go m.TossBytes(ctx, "too few")
+	}
+	if _, err := managers[0].TossBytes(ctx, "too few"); err == nil{
+		t.Fatal("t nodes tossed the coin")
+	}
+}
+
+func TestForgedShare(t *testing.T){
+	managers, nodes := setupManagers(t, 4, 1, 3)
+
+	//node3 proves a share of a key other than its own before the others toss,
+	//it must not make them disagree
+	forged, err := ssecret_sharing.RandomScalar(rand.Reader)
+	if err != nil{
+		t.Fatal(err)
+	}
+	point := hashToPoint("forged")
+	share := new(edwards25519.Point).ScalarMult(forged, point)
+	proof, err := proveDleq(forged, point, new(edwards25519.Point).ScalarBaseMult(forged), share, rand.Reader)
+	if err != nil{
+		t.Fatal(err)
+	}
+	msg := messages.MsgCoin{
+		SenderID:	nodes[3].ID().String(),
+		Name:			"forged",
+		Share:		share.Bytes(),
+		Challenge:	proof.challenge.Bytes(),
+		Response:	proof.response.Bytes(),
+	}
+	if _, err := managers[0].verify(msg); err == nil{
+		t.Fatal("forged share verified")
+	}
+	if err := nodes[3].OmniPublisher(&msg); err != nil{
+		t.Fatal(err)
+	}
+
+	toss(t, managers, "forged")
+}
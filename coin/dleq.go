@@ -0,0 +1,109 @@
+package coin
+
+import(
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"io"
+
+	"filippo.io/edwards25519"
+	"github.com/pkg/errors"
+
+	"distry/ssecret_sharing"
+)
+
+//domain separation tags, so hashes of the coin can't be confused with each other
+const(
+	domainPoint	= "distry coin point"
+	domainProof	= "distry coin proof"
+	domainValue	= "distry coin value"
+)
+
+//hashToPoint maps the coin name onto a point of the prime order subgroup whose discrete log
+//nobody knows: hash with a counter until the hash decodes to a point (try-and-increment),
+//then clear the cofactor.
+func hashToPoint(name string) *edwards25519.Point{
+	identity := edwards25519.NewIdentityPoint()
+	var ctr [4]byte
+	for i := uint32(0); ; i++{
+		binary.BigEndian.PutUint32(ctr[:], i)
+		h := sha256.New()
+		h.Write([]byte(domainPoint))
+		h.Write([]byte(name))
+		h.Write(ctr[:])
+		p, err := new(edwards25519.Point).SetBytes(h.Sum(nil))
+		if err != nil{
+			continue
+		}
+		p.MultByCofactor(p)
+		if p.Equal(identity) == 1{
+			continue
+		}
+		return p
+	}
+}
+
+//hashToScalar is the random oracle of the fiat-shamir transform
+func hashToScalar(points ...*edwards25519.Point) *edwards25519.Scalar{
+	h := sha512.New()
+	h.Write([]byte(domainProof))
+	for _, p := range points{
+		h.Write(p.Bytes())
+	}
+	s, err := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	if err != nil{
+		panic(err) //can't happen, sha512 gives 64 bytes
+	}
+	return s
+}
+
+//proof that log_B(vk) = log_H(share) (chaum-pedersen), made non-interactive with fiat-shamir:
+//the prover picks r, commits to a1 = r*B and a2 = r*H, and answers the challenge
+//c = hash(B, H, vk, share, a1, a2) with z = r + c*x
+type dleqProof struct{
+	challenge	*edwards25519.Scalar
+	response		*edwards25519.Scalar
+}
+
+func proveDleq(x *edwards25519.Scalar, point, verificationKey, share *edwards25519.Point, rand io.Reader) (dleqProof, error){
+	r, err := ssecret_sharing.RandomScalar(rand)
+	if err != nil{
+		return dleqProof{}, err
+	}
+	a1 := new(edwards25519.Point).ScalarBaseMult(r)
+	a2 := new(edwards25519.Point).ScalarMult(r, point)
+	c := hashToScalar(edwards25519.NewGeneratorPoint(), point, verificationKey, share, a1, a2)
+	z := edwards25519.NewScalar().MultiplyAdd(c, x, r)
+	return dleqProof{challenge: c, response: z}, nil
+}
+
+//a1 = z*B - c*vk and a2 = z*H - c*share are the commitments of an honest prover
+func verifyDleq(proof dleqProof, point, verificationKey, share *edwards25519.Point) bool{
+	minusC := edwards25519.NewScalar().Negate(proof.challenge)
+	a1 := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(minusC, verificationKey, proof.response)
+	a2 := new(edwards25519.Point).VarTimeMultiScalarMult(
+		[]*edwards25519.Scalar{proof.response, minusC},
+		[]*edwards25519.Point{point, share},
+	)
+	c := hashToScalar(edwards25519.NewGeneratorPoint(), point, verificationKey, share, a1, a2)
+	return c.Equal(proof.challenge) == 1
+}
+
+//decode a share and its proof as received over the network.
+//a share may carry a small order component which a forger can get past verifyDleq with some
+//grinding, combine takes care of it.
+func decodeShare(share, challenge, response []byte) (*edwards25519.Point, dleqProof, error){
+	p, err := new(edwards25519.Point).SetBytes(share)
+	if err != nil{
+		return nil, dleqProof{}, errors.Wrap(err, "decoding share")
+	}
+	c, err := edwards25519.NewScalar().SetCanonicalBytes(challenge)
+	if err != nil{
+		return nil, dleqProof{}, errors.Wrap(err, "decoding challenge")
+	}
+	z, err := edwards25519.NewScalar().SetCanonicalBytes(response)
+	if err != nil{
+		return nil, dleqProof{}, errors.Wrap(err, "decoding response")
+	}
+	return p, dleqProof{challenge: c, response: z}, nil
+}
@@ -0,0 +1,206 @@
+package coin
+
+import(
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"filippo.io/edwards25519"
+	"github.com/pkg/errors"
+
+	"distry/ssecret_sharing"
+)
+
+//Holder is a node holding a share of the coin key
+type Holder struct{
+	Index					uint32 //x coordinate of the node's share
+	VerificationKey	*edwards25519.Point //the base point multiplied by the node's share
+}
+
+//Keys are the keys of a single node for the threshold coin.
+//the coin secret key x is shamir-shared with threshold T+1 among the holders,
+//nobody needs to know x itself.
+type Keys struct{
+	T			int //any T+1 coin shares combine into the coin
+	Index		uint32 //index of this node's share
+	Share		*edwards25519.Scalar //x_i, this node's share of x
+	PublicKey	*edwards25519.Point //the base point multiplied by x
+	//map [ PEER_ID -> Holder ]
+	Holders	map[string]Holder
+}
+
+//Deal creates the keys of every peer with a trusted dealer: x is drawn at random and
+//shamir-shared among peers, any t+1 of them can toss coins.
+//the dealer knows x and can predict every coin, it must forget it (and the keys of other peers).
+func Deal(peerIDs []string, t int, rand io.Reader) (map[string]*Keys, error){
+	if t < 0 || 2*t >= len(peerIDs){
+		return nil, errors.Errorf("can't deal coin keys to %d peers with t=%d", len(peerIDs), t)
+	}
+
+	secret, err := ssecret_sharing.RandomScalar(rand)
+	if err != nil{
+		return nil, err
+	}
+	commitments, shares, err := ssecret_sharing.SplitVerifiable(secret, t+1, len(peerIDs), rand)
+	if err != nil{
+		return nil, err
+	}
+
+	holders := make(map[string]Holder, len(peerIDs))
+	for i, peerID := range peerIDs{
+		if _, exists := holders[peerID]; exists{
+			return nil, errors.Errorf("peer %s repeats", peerID)
+		}
+		holders[peerID] = Holder{
+			Index:				shares[i].Index,
+			VerificationKey:	commitments.Eval(shares[i].Index),
+		}
+	}
+
+	publicKey := commitments.PublicKey()
+	keys := make(map[string]*Keys, len(peerIDs))
+	for i, peerID := range peerIDs{
+		keys[peerID] = &Keys{
+			T:				t,
+			Index:		shares[i].Index,
+			Share:		shares[i].Value,
+			PublicKey:	publicKey,
+			Holders:		holders,
+		}
+	}
+	return keys, nil
+}
+
+//Check returns an error if the share of the node does not match its verification key
+func (k *Keys) Check(peerID string) error{
+	holder, exists := k.Holders[peerID]
+	if !exists{
+		return errors.Errorf("%s holds no coin share", peerID)
+	}
+	if holder.Index != k.Index{
+		return errors.Errorf("share index %d of %s does not match its holder index %d", k.Index, peerID, holder.Index)
+	}
+	if new(edwards25519.Point).ScalarBaseMult(k.Share).Equal(holder.VerificationKey) != 1{
+		return errors.Errorf("coin share of %s does not match its verification key", peerID)
+	}
+	if len(k.Holders) <= 2*k.T{
+		return errors.Errorf("%d holders can't tolerate t=%d", len(k.Holders), k.T)
+	}
+	return nil
+}
+
+
+//json encoding of Keys, scalars and points are hex encoded
+type keysFile struct{
+	T			int						`json:"t"`
+	Index		uint32					`json:"index"`
+	Share		string					`json:"share"`
+	PublicKey	string					`json:"public_key"`
+	Holders	map[string]holderFile	`json:"holders"`
+}
+type holderFile struct{
+	Index					uint32	`json:"index"`
+	VerificationKey	string	`json:"verification_key"`
+}
+
+func (k *Keys) MarshalJSON() ([]byte, error){
+	kf := keysFile{
+		T:				k.T,
+		Index:		k.Index,
+		Share:		hex.EncodeToString(k.Share.Bytes()),
+		PublicKey:	hex.EncodeToString(k.PublicKey.Bytes()),
+		Holders:		make(map[string]holderFile, len(k.Holders)),
+	}
+	for peerID, holder := range k.Holders{
+		kf.Holders[peerID] = holderFile{
+			Index:				holder.Index,
+			VerificationKey:	hex.EncodeToString(holder.VerificationKey.Bytes()),
+		}
+	}
+	return json.Marshal(kf)
+}
+
+func (k *Keys) UnmarshalJSON(data []byte) error{
+	var kf keysFile
+	if err := json.Unmarshal(data, &kf); err != nil{
+		return err
+	}
+
+	share, err := decodeScalar(kf.Share)
+	if err != nil{
+		return errors.Wrap(err, "decoding share")
+	}
+	publicKey, err := decodePoint(kf.PublicKey)
+	if err != nil{
+		return errors.Wrap(err, "decoding public key")
+	}
+	holders := make(map[string]Holder, len(kf.Holders))
+	for peerID, hf := range kf.Holders{
+		verificationKey, err := decodePoint(hf.VerificationKey)
+		if err != nil{
+			return errors.Wrapf(err, "decoding verification key of %s", peerID)
+		}
+		holders[peerID] = Holder{Index: hf.Index, VerificationKey: verificationKey}
+	}
+
+	*k = Keys{
+		T:				kf.T,
+		Index:		kf.Index,
+		Share:		share,
+		PublicKey:	publicKey,
+		Holders:		holders,
+	}
+	return nil
+}
+
+func decodeScalar(s string) (*edwards25519.Scalar, error){
+	raw, err := hex.DecodeString(s)
+	if err != nil{
+		return nil, err
+	}
+	return edwards25519.NewScalar().SetCanonicalBytes(raw)
+}
+
+func decodePoint(s string) (*edwards25519.Point, error){
+	raw, err := hex.DecodeString(s)
+	if err != nil{
+		return nil, err
+	}
+	return new(edwards25519.Point).SetBytes(raw)
+}
+
+//Save writes the keys to the new file fileName, readable by the owner only, as the share is secret.
+//existing keys are never replaced, errors.Is(err, os.ErrExist) tells whether the file exists
+func (k *Keys) Save(fileName string) error{
+	data, err := json.MarshalIndent(k, "", "\t")
+	if err != nil{
+		return errors.Wrap(err, "encoding coin keys")
+	}
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil{
+		return errors.Wrap(err, "writing coin keys")
+	}
+	if _, err := f.Write(data); err != nil{
+		f.Close()
+		return errors.Wrap(err, "writing coin keys")
+	}
+	return errors.Wrap(f.Close(), "writing coin keys")
+}
+
+//LoadKeys reads the keys saved by Save, os.IsNotExist tells whether the file is missing
+func LoadKeys(fileName string) (*Keys, error){
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil{
+		if os.IsNotExist(err){
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "reading coin keys")
+	}
+	keys := new(Keys)
+	if err := json.Unmarshal(data, keys); err != nil{
+		return nil, errors.Wrap(err, "decoding coin keys")
+	}
+	return keys, nil
+}
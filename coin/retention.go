@@ -0,0 +1,47 @@
+package coin
+
+import(
+	"time"
+
+	"go.uber.org/zap"
+
+	"distry/internal/retention"
+)
+
+//current coin counts of the node, served by expvar under /debug/vars
+var metrics = retention.NewMetrics("coin")
+
+//evict coins with a value which are too old or too many and coins which stalled, like rbc0 does.
+//a late share of an evicted coin starts it anew, and it is evicted again once it stalls.
+func (m *Manager) gc(now time.Time){
+	evictedDone := retention.Expired(now, m.cfg.AcceptedMaxAge, m.cfg.AcceptedMaxCount, len(m.doneOrder), func(i int) time.Time{
+		return m.coinInfoMap[m.doneOrder[i]].doneAt
+	})
+	for _, name := range m.doneOrder[:evictedDone]{
+		delete(m.coinInfoMap, name)
+	}
+	m.doneOrder = m.doneOrder[evictedDone:]
+
+	evictedStalled := 0
+	for name, ci := range m.coinInfoMap{
+		if ci.value == nil && retention.Stalled(now, ci.lastActivity, m.cfg.StalledTimeout){
+			m.logger.Info("evicting stalled coin",
+				zap.String("name", name),
+				zap.Int("shares", len(ci.shares)),
+			)
+			delete(m.coinInfoMap, name)
+			evictedStalled++
+		}
+	}
+
+	metrics.Add("coins_evicted_done", int64(evictedDone))
+	metrics.Add("coins_evicted_stalled", int64(evictedStalled))
+	m.updateMetrics()
+}
+
+//publish the current coin counts
+func (m *Manager) updateMetrics(){
+	done := len(m.doneOrder)
+	metrics.SetGauge("coins_active", len(m.coinInfoMap) - done)
+	metrics.SetGauge("coins_done", done)
+}
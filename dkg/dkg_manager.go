@@ -0,0 +1,976 @@
+package dkg
+
+import(
+	"context"
+	"crypto/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"filippo.io/edwards25519"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"distry/acs"
+	"distry/coin"
+	"distry/internal/retention"
+	"distry/membership"
+	"distry/messages"
+	genmsg "distry/proto_gen/messages"
+	"distry/rbc0"
+	"distry/ssecret_sharing"
+)
+
+//rbc0 streams of the messages broadcast by the dealers, the counter of their round is the session
+const(
+	streamDeal			= "dkgdeal"
+	streamComplaint	= "dkgcomplaint"
+	streamAnswer		= "dkganswer"
+	streamKey			= "dkgkey"
+)
+
+//types of dkg messages, see proto/messages.proto
+const(
+	typeShare		uint32 = 1
+	typeDeal			uint32 = 2
+	typeComplaint	uint32 = 3
+	typeAnswer		uint32 = 4
+	typeKey			uint32 = 5
+)
+
+//phases of a session, each but phaseAgree lasts Config.PhaseTimeout
+const(
+	phaseDeal		= iota //dealers broadcast their commitments and send the shares
+	phaseComplain	//nodes complain against dealers whose share was missing or invalid
+	phaseAnswer		//dealers reveal the shares of the complaining nodes
+	phaseAgree		//nodes agree on the qualified dealers, until their DEALs are delivered
+	phaseConfirm	//nodes broadcast the public key they generated
+	phaseDone
+)
+
+//SHAREs of sessions this node did not join yet are held for at most this many sessions:
+//a dealer's SHAREs travel directly, so they arrive before the DEAL which makes a node join
+const maxPendingSessions = 4
+
+//Config holds the settings of the key generation
+type Config struct{
+	//long enough for a broadcast to reach every node: a DEAL which comes later is not complained
+	//against, and counts only if the agreement on its dealer qualifies it
+	PhaseTimeout time.Duration
+}
+
+//DefaultConfig returns the settings used when none are given
+func DefaultConfig() Config{
+	return Config{PhaseTimeout: 5*time.Second}
+}
+
+//Rbc is the part of rbc0.Manager dkg relies on
+type Rbc interface{
+	BroadcastInstance(ctx context.Context, stream string, counter uint64, payload string) (messages.Rbc0Delivery, error)
+	SubscribeToMessages() messages.Subscriber
+}
+
+//Agreement is the part of aba.Manager dkg relies on
+type Agreement interface{
+	Agree(ctx context.Context, sessionID string, value bool) (messages.AbaDecision, error)
+}
+
+//Omni is the part of omni.Manager dkg relies on
+type Omni interface{
+	ID() peer.ID
+	OmniPublisher(messages.Message) error
+	SendOrPublish(peer.ID, messages.Message)
+	Register(messages.Codec)
+}
+
+//Membership is the part of membership.Manager dkg relies on
+type Membership interface{
+	Current() membership.Epoch
+}
+
+//Result is handed by dkg to other parts of the node once a session is over.
+//it never leaves the node. It is marshalled as a DKG KEY message carrying the public key.
+type Result struct{
+	Session		uint64
+	Keys			*coin.Keys //nil if the session failed
+	Qualified	[]string //sorted IDs of the dealers whose sharings make up the key
+	Err			error
+}
+func (r Result) MarshalToProtobuf() *genmsg.Message{
+	var publicKey []byte
+	if r.Keys != nil{
+		publicKey = r.Keys.PublicKey.Bytes()
+	}
+	return &genmsg.Message{
+		Type: genmsg.Message_DKG,
+		Dkg: &genmsg.Dkg{
+			Session:			r.Session,
+			Type:				typeKey,
+			Commitments:	publicKey,
+		},
+	}
+}
+
+//struct to keep info on a key generation session
+type sessionInfo struct{
+	phase		int
+	n, t		int
+	//map [ PEER_ID -> SHARE_INDEX ]
+	//holders of the key: the peers of the membership epoch current when the session started.
+	//the holders are pinned by view, a digest of them, see acs.View
+	holders	map[string]uint32
+	view		string
+	poly		ssecret_sharing.ScalarPolynomial //this node's sharing
+
+	//map [ DEALER_ID -> commitments ]
+	//commitments of the DEALs delivered by rbc0
+	commitments	map[string]ssecret_sharing.Commitments
+	//map [ DEALER_ID -> share ]
+	//shares the dealers sent this node, checked against their commitments once the deal phase is over
+	shares		map[string]*edwards25519.Scalar
+	//set of DEALER_IDs this node complained against
+	accused		map[string]struct{}
+	//map [ DEALER_ID -> set of COMPLAINER_IDs ]
+	complaints	map[string]map[string]struct{}
+	//map [ DEALER_ID -> map [ COMPLAINER_ID -> share ] ]
+	//shares revealed in the ANSWERs of the dealers
+	reveals		map[string]map[string]*edwards25519.Scalar
+	//map [ DEALER_ID -> DECISION ]
+	//decisions of the agreements, true qualifies the dealer
+	decisions	map[string]bool
+	//keys this node generated, kept once n-t holders confirm its public key
+	generated	*Result
+	//map [ HOLDER_ID -> PUBLIC_KEY ]
+	//public keys the holders broadcast in the confirm phase
+	publicKeys	map[string][]byte
+
+	result	*Result //nil until the session is over
+	doneAt	time.Time
+}
+
+//events handled by the event loop, see eventLoop
+type runEvent struct{
+	session	uint64
+	resultC	chan<- runResult
+}
+type runResult struct{
+	result	*Result //set if the session is already over
+	err		error
+}
+type phaseEvent struct{
+	session	uint64
+	phase		int
+}
+type decisionEvent struct{
+	session	uint64
+	dealer	string
+	value		bool
+	err		error //set if the agreement is not decided, value is then the input to retry with
+}
+
+//SHAREs received before this node joined their session
+type pendingShares struct{
+	//map [ DEALER_ID -> SHARE ]
+	shares		map[string]messages.MsgDkg
+	receivedAt	time.Time
+}
+
+//Manager runs the distributed key generation of pedersen (a threshold cryptosystem without
+//a trusted party, 1991), in the joint-feldman form of gennaro et al. every node is a dealer:
+//	- deal: broadcast feldman commitments to a random polynomial of degree t with rbc0 and
+//	  send every node its share, encrypted to the node's identity key
+//	- complain: broadcast a complaint against every dealer whose share does not match its commitments
+//	- answer: broadcast the shares of the nodes which complained, in the clear
+//	- agree: input to a binary agreement per dealer whether it qualified for this node
+//	- confirm: broadcast the public key this node generated
+//a dealer qualifies for a node if its DEAL was delivered, it got at most t complaints and it
+//answered each with a valid share. The dealers whose agreement decides true are qualified on
+//every node, whichever broadcasts arrived before its phases ended.
+//the key is the sum of the secrets of the qualified dealers, the share of a node is the sum
+//of its shares, and nobody ever holds the key itself. A node only keeps its keys if n-t holders
+//confirm the same public key. A node joins a session on RunDKG or on the DEAL of a peer.
+//nodes only count the messages of their view, so every session is held by nodes with the same holders.
+type Manager struct{
+	logger	*zap.Logger
+	cfg		rbc0.Config
+	dkgCfg	Config
+	nodeID	string
+	identity	*edwards25519.Scalar //scalar of the node's identity key, decrypts shares
+	omniManager Omni
+	rbc		Rbc
+	agreement	Agreement
+	membershipManager Membership
+
+	msgC			chan messages.MsgDkg //SHAREs sent to this node over the omni network
+	deliveryC	chan messages.Rbc0Delivery //DEALs, COMPLAINTs and ANSWERs delivered by rbc0
+	runC			chan runEvent
+	phaseC		chan phaseEvent
+	decisionC	chan decisionEvent
+
+	//map [ SESSION -> sessionInfo ]
+	sessionInfoMap	map[uint64]*sessionInfo
+	//map [ SESSION -> pendingShares ]
+	//SHAREs of peers for sessions this node did not join yet
+	pending			map[uint64]*pendingShares
+	//sessions which are over and still in sessionInfoMap, in the order they ended
+	doneOrder		[]uint64
+	//evicted sessions, their messages are dropped
+	lowWater			*retention.LowWater
+
+	//this Manager sends Results via msgPublisher to the subscribers
+	msgPublisher	messages.Publisher
+	subscribers		messages.Fanout
+}
+
+//privKey is the Ed25519 identity key of the node
+func NewManager(logger *zap.Logger, cfg rbc0.Config, dkgCfg Config, privKey crypto.PrivKey, membershipManager Membership, omniManager Omni, rbc Rbc, agreement Agreement) (*Manager, error){
+	if logger == nil{
+		logger = zap.NewNop()
+	}
+	cfg = cfg.WithDefaults()
+	if dkgCfg.PhaseTimeout <= 0{
+		dkgCfg.PhaseTimeout = DefaultConfig().PhaseTimeout
+	}
+	identity, err := identityScalar(privKey)
+	if err != nil{
+		return nil, err
+	}
+
+	pub, sub := messages.NewSubscription()
+
+	m := &Manager{
+		logger:			logger,
+		cfg:				cfg,
+		dkgCfg:			dkgCfg,
+		nodeID:			omniManager.ID().String(),
+		identity:		identity,
+		omniManager:	omniManager,
+		rbc:				rbc,
+		agreement:		agreement,
+		membershipManager:	membershipManager,
+		msgC:				make(chan messages.MsgDkg),
+		deliveryC:		make(chan messages.Rbc0Delivery),
+		runC:				make(chan runEvent),
+		phaseC:			make(chan phaseEvent),
+		decisionC:		make(chan decisionEvent),
+		sessionInfoMap:	make(map[uint64]*sessionInfo),
+		pending:			make(map[uint64]*pendingShares),
+		doneOrder:		make([]uint64, 0),
+		lowWater:		retention.NewLowWater(cfg.EvictedWindow),
+		msgPublisher:	pub,
+	}
+
+	go m.subscribers.Run(sub, func(dropped uint64){ metrics.Add("messages_dropped", int64(dropped)) })
+	go m.eventLoop()
+	m.omniManager.Register(messages.Codec{
+		Type:				genmsg.Message_DKG,
+		Marshal:			messages.MarshalDkg,
+		Unmarshal:		messages.UnmarshalDkg,
+		Handler:			m.handleOmniMsg,
+	})
+	go m.rbcReceiver()
+	return m, nil
+}
+
+//the only goroutine which reads or changes protocol state
+func (m *Manager) eventLoop(){
+	gcTicker := time.NewTicker(m.cfg.GCInterval)
+	defer gcTicker.Stop()
+
+	for{
+		select{
+			case msg := <-m.msgC:
+				m.handleShare(msg)
+			case delivery := <-m.deliveryC:
+				m.handleDelivery(delivery)
+			case ev := <-m.runC:
+				ev.resultC <- m.run(ev.session)
+			case ev := <-m.phaseC:
+				m.endPhase(ev.session, ev.phase)
+			case ev := <-m.decisionC:
+				m.handleDecision(ev)
+			case now := <-gcTicker.C:
+				m.gc(now)
+		}
+	}
+}
+
+//pass on the SHAREs meant for this node
+func (m *Manager) handleOmniMsg(in messages.Message){
+	msg, ok := messages.Unwrap(in).(messages.MsgDkg)
+	if !ok || msg.Type != typeShare || msg.RecipientID != m.nodeID{
+		return
+	}
+	m.msgC <- msg
+}
+
+//pass on the rbc0 deliveries of the dkg streams
+func (m *Manager) rbcReceiver(){
+	sub := m.rbc.SubscribeToMessages()
+
+	for{
+		msg, err := sub.Next()
+		if err != nil{
+			m.logger.Error("failed receiving msg from rbc0", zap.Error(err))
+			continue
+		}
+
+		delivery, ok := msg.(messages.Rbc0Delivery)
+		if !ok{
+			continue
+		}
+		if _, stream, _, ok := rbc0.ParseProtocolID(delivery.ProtocolID); !ok || (stream != streamDeal && stream != streamComplaint && stream != streamAnswer && stream != streamKey){
+			continue
+		}
+		m.deliveryC <- delivery
+	}
+}
+
+//return the info on session, joining it if this node did not yet.
+//nil is returned for sessions which were evicted
+func (m *Manager) join(session uint64) *sessionInfo{
+	si, exists := m.sessionInfoMap[session]
+	if exists{
+		return si
+	}
+	if m.lowWater.IsLate("", session){
+		return nil
+	}
+
+	epoch := m.membershipManager.Current()
+	si = &sessionInfo{
+		n:				epoch.N,
+		t:				rbc0.MaxFaulty(epoch.N),
+		holders:		make(map[string]uint32, len(epoch.Peers)),
+		view:			acs.View(epoch),
+		commitments:	make(map[string]ssecret_sharing.Commitments),
+		shares:		make(map[string]*edwards25519.Scalar),
+		accused:		make(map[string]struct{}),
+		complaints:	make(map[string]map[string]struct{}),
+		reveals:		make(map[string]map[string]*edwards25519.Scalar),
+		decisions:	make(map[string]bool),
+		publicKeys:	make(map[string][]byte),
+	}
+	//epoch.Peers are sorted, so every node numbers the holders the same way
+	for i, p := range epoch.Peers{
+		si.holders[p.String()] = uint32(i + 1)
+	}
+	m.sessionInfoMap[session] = si
+	m.updateMetrics()
+
+	m.logger.Info("joining dkg session",
+		zap.Uint64("session", session),
+		zap.Int("n", si.n),
+		zap.Int("t", si.t),
+		zap.String("view", si.view),
+	)
+	m.deal(session)
+
+	if pending, exists := m.pending[session]; exists{
+		delete(m.pending, session)
+		for _, msg := range pending.shares{
+			m.handleShare(msg)
+		}
+	}
+	return si
+}
+
+func isPeer(epoch membership.Epoch, nodeID string) bool{
+	for _, p := range epoch.Peers{
+		if p.String() == nodeID{
+			return true
+		}
+	}
+	return false
+}
+
+//deal this node's sharing and start the clock of the phases
+func (m *Manager) deal(session uint64){
+	si := m.sessionInfoMap[session]
+	time.AfterFunc(m.dkgCfg.PhaseTimeout, func(){ m.phaseC <- phaseEvent{session: session, phase: phaseDeal} })
+
+	index, exists := si.holders[m.nodeID]
+	if !exists{
+		m.logger.Warn("node holds no share of dkg session, not dealing", zap.Uint64("session", session))
+		return
+	}
+	secret, err := ssecret_sharing.RandomScalar(rand.Reader)
+	if err != nil{
+		m.logger.Error("failed dealing dkg sharing", zap.Uint64("session", session), zap.Error(err))
+		return
+	}
+	poly, err := ssecret_sharing.NewScalarPolynomial(secret, si.t, rand.Reader)
+	if err != nil{
+		m.logger.Error("failed dealing dkg sharing", zap.Uint64("session", session), zap.Error(err))
+		return
+	}
+	si.poly = poly
+	si.shares[m.nodeID] = poly.Eval(index)
+
+	for peerID, index := range si.holders{
+		if peerID == m.nodeID{
+			continue
+		}
+		aead, err := shareCipher(m.identity, peerID, session, m.nodeID, peerID)
+		if err != nil{
+			m.logger.Error("failed encrypting dkg share", zap.String("recipientID", peerID), zap.Error(err))
+			continue
+		}
+		sealed, err := encryptShare(aead, poly.Eval(index), rand.Reader)
+		if err != nil{
+			m.logger.Error("failed encrypting dkg share", zap.String("recipientID", peerID), zap.Error(err))
+			continue
+		}
+		msg := messages.MsgDkg{
+			Session:			session,
+			Type:				typeShare,
+			RecipientID:	peerID,
+			Share:			sealed,
+		}
+		//only the recipient gets its share, or everyone over the omni topic if no direct stream works
+		if id, err := peer.Decode(peerID); err == nil{
+			m.omniManager.SendOrPublish(id, &msg)
+		} else if err := m.omniManager.OmniPublisher(&msg); err != nil{
+			m.logger.Error("sending dkg share FAILED", zap.String("recipientID", peerID))
+		}
+	}
+
+	m.broadcast(session, streamDeal, messages.MsgDkg{Type: typeDeal, Commitments: poly.Commit().Bytes()})
+}
+
+//reliably broadcast msg as this node's round of stream in session, after the view of the session
+func (m *Manager) broadcast(session uint64, stream string, msg messages.MsgDkg){
+	msg.SenderID = m.nodeID
+	msg.Session = session
+	encoded, err := msg.MarshalToProtobuf().Marshal()
+	if err != nil{
+		m.logger.Error("failed encoding dkg message", zap.Error(err))
+		return
+	}
+	payload := m.sessionInfoMap[session].view + string(encoded)
+
+	go func(){
+		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.StalledTimeout)
+		defer cancel()
+		if _, err := m.rbc.BroadcastInstance(ctx, stream, session, payload); err != nil{
+			m.logger.Error("failed broadcasting dkg message",
+				zap.Uint64("session", session),
+				zap.String("stream", stream),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+func (m *Manager) handleShare(msg messages.MsgDkg){
+	si, exists := m.sessionInfoMap[msg.Session]
+	if !exists{
+		m.hold(msg)
+		return
+	}
+	if si.phase >= phaseConfirm{
+		return
+	}
+	if _, exists := si.holders[msg.SenderID]; !exists{
+		return
+	}
+	if _, exists := si.shares[msg.SenderID]; exists{ //only the first SHARE of a dealer counts
+		return
+	}
+
+	aead, err := shareCipher(m.identity, msg.SenderID, msg.Session, msg.SenderID, m.nodeID)
+	if err == nil{
+		var share *edwards25519.Scalar
+		if share, err = decryptShare(aead, msg.Share); err == nil{
+			si.shares[msg.SenderID] = share
+			return
+		}
+	}
+	//the dealer is complained against once the deal phase is over, or left without a share
+	m.logger.Warn("dkg discarding share", zap.String("dealerID", msg.SenderID), zap.Error(err))
+}
+
+//keep the SHARE of a peer until this node joins its session, the SHAREs of others never start one
+func (m *Manager) hold(msg messages.MsgDkg){
+	if m.lowWater.IsLate("", msg.Session) || !isPeer(m.membershipManager.Current(), msg.SenderID){
+		return
+	}
+	pending, exists := m.pending[msg.Session]
+	if !exists{
+		if len(m.pending) >= maxPendingSessions{
+			m.logger.Warn("dkg discarding share of a session not joined",
+				zap.Uint64("session", msg.Session),
+				zap.String("dealerID", msg.SenderID),
+			)
+			return
+		}
+		pending = &pendingShares{shares: make(map[string]messages.MsgDkg), receivedAt: time.Now()}
+		m.pending[msg.Session] = pending
+	}
+	if _, exists := pending.shares[msg.SenderID]; !exists{ //only the first SHARE of a dealer counts
+		pending.shares[msg.SenderID] = msg
+	}
+}
+
+func (m *Manager) handleDelivery(delivery messages.Rbc0Delivery){
+	initiator, stream, session, _ := rbc0.ParseProtocolID(delivery.ProtocolID)
+	si, exists := m.sessionInfoMap[session]
+	view := acs.View(m.membershipManager.Current())
+	if exists{
+		view = si.view
+	}
+	if len(delivery.Payload) < len(view) || delivery.Payload[:len(view)] != view{
+		//the sender doesn't see the same holders, its message is left out like a missing one
+		m.logger.Warn("dkg discarding message of another view",
+			zap.Uint64("session", session),
+			zap.String("senderID", initiator),
+			zap.String("view", view),
+		)
+		return
+	}
+	pb := new(genmsg.Message)
+	if err := pb.Unmarshal([]byte(delivery.Payload[len(view):])); err != nil || pb.Type != genmsg.Message_DKG{
+		m.logger.Warn("dkg discarding undecodable payload", zap.String("protocolID", delivery.ProtocolID))
+		return
+	}
+	decoded, err := messages.UnmarshalDkg(pb)
+	if err != nil{
+		m.logger.Warn("dkg discarding undecodable payload", zap.String("protocolID", delivery.ProtocolID), zap.Error(err))
+		return
+	}
+	msg := decoded.(messages.MsgDkg)
+
+	if !exists{
+		//only the DEAL of a peer makes this node join a session
+		if stream != streamDeal || msg.Type != typeDeal || !isPeer(m.membershipManager.Current(), initiator){
+			m.logger.Debug("dkg discarding message of a session not joined",
+				zap.Uint64("session", session),
+				zap.String("protocolID", delivery.ProtocolID),
+			)
+			return
+		}
+		if si = m.join(session); si == nil{
+			return
+		}
+	}
+	if _, exists := si.holders[initiator]; !exists{
+		m.logger.Warn("dkg discarding message of a node which holds no share",
+			zap.Uint64("session", session),
+			zap.String("senderID", initiator),
+		)
+		return
+	}
+
+	//rbc0 accepts a round only once, so there is a single message per sender, stream and session.
+	//DEALs and ANSWERs delivered late still count for the dealers the agreements qualify
+	switch{
+		case stream == streamDeal && msg.Type == typeDeal && si.phase < phaseConfirm:
+			commitments, err := ssecret_sharing.DecodeCommitments(msg.Commitments)
+			if err != nil || commitments.Threshold() != si.t+1{
+				m.logger.Warn("dkg discarding malformed deal", zap.String("dealerID", initiator))
+				return
+			}
+			si.commitments[initiator] = commitments
+			m.checkAgreed(session)
+		case stream == streamComplaint && msg.Type == typeComplaint && si.phase <= phaseComplain:
+			for _, dealer := range msg.Accused{
+				if _, exists := si.holders[dealer]; !exists{
+					continue
+				}
+				if si.complaints[dealer] == nil{
+					si.complaints[dealer] = make(map[string]struct{})
+				}
+				si.complaints[dealer][initiator] = struct{}{}
+			}
+		case stream == streamAnswer && msg.Type == typeAnswer && si.phase < phaseConfirm:
+			reveals := make(map[string]*edwards25519.Scalar, len(msg.Reveals))
+			for _, reveal := range msg.Reveals{
+				share, err := edwards25519.NewScalar().SetCanonicalBytes(reveal.Share)
+				if err != nil{
+					continue
+				}
+				reveals[reveal.RecipientID] = share
+			}
+			si.reveals[initiator] = reveals
+			m.checkAgreed(session)
+		case stream == streamKey && msg.Type == typeKey && si.phase <= phaseConfirm:
+			si.publicKeys[initiator] = msg.Commitments
+			m.checkConfirmed(session)
+		default:
+			m.logger.Debug("dkg discarding message",
+				zap.Uint64("session", session),
+				zap.String("protocolID", delivery.ProtocolID),
+				zap.Int("phase", si.phase),
+			)
+	}
+}
+
+//end the phase of session on its timeout
+func (m *Manager) endPhase(session uint64, phase int){
+	si, exists := m.sessionInfoMap[session]
+	if !exists || si.phase != phase{
+		return
+	}
+	if phase == phaseAgree{
+		//the phase ends on the decisions, which did not come or qualified a dealer whose DEAL did not
+		si.phase = phaseDone
+		si.generated = &Result{Err: errors.New("qualified dealers not agreed on before the session stalled")}
+		m.finish(session)
+		return
+	}
+	m.nextPhase(session)
+}
+
+//start the next phase of session, with the timeout that ends it
+func (m *Manager) nextPhase(session uint64){
+	si := m.sessionInfoMap[session]
+	si.phase++
+	timeout := m.dkgCfg.PhaseTimeout
+	if si.phase == phaseAgree{
+		timeout = m.cfg.StalledTimeout
+	}
+	if next := si.phase; next != phaseDone{
+		time.AfterFunc(timeout, func(){ m.phaseC <- phaseEvent{session: session, phase: next} })
+	}
+
+	switch si.phase{
+		case phaseComplain:
+			m.complain(session)
+		case phaseAnswer:
+			m.answer(session)
+		case phaseAgree:
+			m.agree(session)
+		case phaseConfirm:
+			m.confirm(session)
+		case phaseDone:
+			m.finish(session)
+	}
+}
+
+//complain against every dealer whose share did not come or does not match its commitments
+func (m *Manager) complain(session uint64){
+	si := m.sessionInfoMap[session]
+	index, exists := si.holders[m.nodeID]
+	if !exists{
+		return
+	}
+
+	accused := make([]string, 0)
+	for dealer, commitments := range si.commitments{
+		share, exists := si.shares[dealer]
+		if exists && commitments.Verify(ssecret_sharing.ScalarShare{Index: index, Value: share}) == nil{
+			continue
+		}
+		delete(si.shares, dealer)
+		si.accused[dealer] = struct{}{}
+		accused = append(accused, dealer)
+	}
+	if len(accused) == 0{
+		return
+	}
+	sort.Strings(accused)
+
+	m.logger.Info("complaining against dkg dealers", zap.Uint64("session", session), zap.Strings("dealerIDs", accused))
+	m.broadcast(session, streamComplaint, messages.MsgDkg{Type: typeComplaint, Accused: accused})
+}
+
+//reveal the shares of the nodes which complained against this node
+func (m *Manager) answer(session uint64){
+	si := m.sessionInfoMap[session]
+	complainers := si.complaints[m.nodeID]
+	if len(complainers) == 0 || si.poly == nil{
+		return
+	}
+
+	reveals := make([]messages.DkgReveal, 0, len(complainers))
+	for complainer := range complainers{
+		share := si.poly.Eval(si.holders[complainer])
+		reveals = append(reveals, messages.DkgReveal{RecipientID: complainer, Share: share.Bytes()})
+	}
+	sort.Slice(reveals, func(i, j int) bool{ return reveals[i].RecipientID < reveals[j].RecipientID })
+
+	m.logger.Info("answering dkg complaints", zap.Uint64("session", session), zap.Int("complaints", len(reveals)))
+	m.broadcast(session, streamAnswer, messages.MsgDkg{Type: typeAnswer, Reveals: reveals})
+}
+
+//input to the agreement of every dealer whether it qualified for this node
+func (m *Manager) agree(session uint64){
+	si := m.sessionInfoMap[session]
+	for dealer := range si.holders{
+		m.agreeOn(session, si.view, dealer, m.qualifies(si, dealer))
+	}
+}
+
+//Agree blocks until the agreement decides, the decision (or the failure) comes back as a decisionEvent
+func (m *Manager) agreeOn(session uint64, view, dealer string, value bool){
+	go func(){
+		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.StalledTimeout)
+		defer cancel()
+		decision, err := m.agreement.Agree(ctx, agreementID(session, view, dealer), value)
+		ev := decisionEvent{session: session, dealer: dealer, value: decision.Value, err: err}
+		if err != nil{
+			ev.value = value
+		}
+		m.decisionC <- ev
+	}()
+}
+
+//the ID of the agreement on dealer in session, among the nodes of view
+func agreementID(session uint64, view, dealer string) string{
+	return "dkg_" + strconv.FormatUint(session, 10) + "_" + view + "_" + dealer
+}
+
+//whether the DEAL of dealer was delivered, it got at most t complaints and answered each with a valid share
+func (m *Manager) qualifies(si *sessionInfo, dealer string) bool{
+	commitments, exists := si.commitments[dealer]
+	if !exists{
+		return false
+	}
+	complainers := si.complaints[dealer]
+	if len(complainers) > si.t{
+		return false
+	}
+	for complainer := range complainers{
+		revealed := si.reveals[dealer][complainer]
+		if revealed == nil || commitments.Verify(ssecret_sharing.ScalarShare{Index: si.holders[complainer], Value: revealed}) != nil{
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Manager) handleDecision(ev decisionEvent){
+	si, exists := m.sessionInfoMap[ev.session]
+	if !exists || si.phase != phaseAgree{
+		return
+	}
+	if _, exists := si.decisions[ev.dealer]; exists{
+		return
+	}
+	if ev.err != nil{
+		//the session can't go on without the decision, keep waiting for it until the phase times out
+		m.logger.Warn("dkg agreement not decided, retrying",
+			zap.Uint64("session", ev.session),
+			zap.String("dealerID", ev.dealer),
+			zap.Error(ev.err),
+		)
+		metrics.Add("agreements_retried", 1)
+		m.agreeOn(ev.session, si.view, ev.dealer, ev.value)
+		return
+	}
+	si.decisions[ev.dealer] = ev.value
+	m.checkAgreed(ev.session)
+}
+
+//once every agreement decided and the DEAL of every qualified dealer was delivered, go on to confirm.
+//an agreement decides true only if some correct node input true, so the DEAL was delivered
+//to that node and rbc0 will deliver it to every correct node. The ANSWER of a qualified dealer
+//this node complained against carries the share of this node, so it is waited for as well.
+func (m *Manager) checkAgreed(session uint64){
+	si := m.sessionInfoMap[session]
+	if si.phase != phaseAgree || len(si.decisions) < len(si.holders){
+		return
+	}
+	for dealer, qualified := range si.decisions{
+		if !qualified{
+			continue
+		}
+		if _, delivered := si.commitments[dealer]; !delivered{
+			return
+		}
+		if _, accused := si.accused[dealer]; accused && si.reveals[dealer] == nil{
+			return
+		}
+	}
+	m.nextPhase(session)
+}
+
+//add up the sharings of the qualified dealers and broadcast the public key
+func (m *Manager) confirm(session uint64){
+	si := m.sessionInfoMap[session]
+	generated, err := m.combine(si)
+	if err != nil{
+		si.generated = &Result{Err: err}
+		return
+	}
+	si.generated = generated
+	si.poly = nil
+	si.shares = nil
+	si.reveals = nil
+	m.broadcast(session, streamKey, messages.MsgDkg{Type: typeKey, Commitments: generated.Keys.PublicKey.Bytes()})
+	m.checkConfirmed(session)
+}
+
+//the number of holders which confirmed the public key this node generated
+func confirmations(si *sessionInfo) int{
+	if si.generated == nil || si.generated.Err != nil{
+		return 0
+	}
+	publicKey := string(si.generated.Keys.PublicKey.Bytes())
+	confirmed := 0
+	for holder := range si.holders{
+		if string(si.publicKeys[holder]) == publicKey{
+			confirmed++
+		}
+	}
+	return confirmed
+}
+
+//end the confirm phase once n-t holders confirmed the public key, without waiting for the rest
+func (m *Manager) checkConfirmed(session uint64){
+	si := m.sessionInfoMap[session]
+	if si.phase == phaseConfirm && confirmations(si) >= si.n - si.t{
+		m.nextPhase(session)
+	}
+}
+
+//keep the generated keys if n-t holders confirmed their public key.
+//every correct node combines the sharings of the same qualified dealers, so they confirm a single key
+func (m *Manager) finish(session uint64){
+	si := m.sessionInfoMap[session]
+	result, err := si.generated, si.generated.Err
+	if err == nil{
+		if confirmed := confirmations(si); confirmed < si.n - si.t{
+			err = errors.Errorf("%d holders confirmed the public key, at least %d are needed", confirmed, si.n - si.t)
+		}
+	}
+	if err != nil{
+		result = &Result{Err: err}
+		m.logger.Error("dkg session FAILED", zap.Uint64("session", session), zap.Error(err))
+	} else{
+		m.logger.Info("dkg session DONE",
+			zap.Uint64("session", session),
+			zap.Strings("qualified", result.Qualified),
+		)
+	}
+	result.Session = session
+
+	si.result = result
+	si.doneAt = time.Now()
+	si.poly = nil
+	si.shares = nil
+	si.reveals = nil
+	si.generated = nil
+	si.publicKeys = nil
+	si.decisions = nil
+	m.doneOrder = append(m.doneOrder, session)
+	m.updateMetrics()
+
+	if err := m.msgPublisher.Publish(*result); err != nil{
+		m.logger.Error("failed passing dkg result to the subscribers")
+	}
+}
+
+func (m *Manager) combine(si *sessionInfo) (*Result, error){
+	index, exists := si.holders[m.nodeID]
+	if !exists{
+		return nil, errors.New("node holds no share")
+	}
+
+	qualified := make([]string, 0, len(si.decisions))
+	for dealer, value := range si.decisions{
+		if value{
+			qualified = append(qualified, dealer)
+		}
+	}
+	sort.Strings(qualified)
+
+	var commitments ssecret_sharing.Commitments
+	share := edwards25519.NewScalar()
+	for _, dealer := range qualified{
+		dealerCommitments := si.commitments[dealer]
+		//the SHARE of a dealer whose DEAL came late was never checked
+		dealerShare := si.shares[dealer]
+		if dealerShare == nil || dealerCommitments.Verify(ssecret_sharing.ScalarShare{Index: index, Value: dealerShare}) != nil{
+			dealerShare = si.reveals[dealer][m.nodeID]
+		}
+		if dealerShare == nil || dealerCommitments.Verify(ssecret_sharing.ScalarShare{Index: index, Value: dealerShare}) != nil{
+			//the dealer sent no valid share to this node, and this node's complaint did not count
+			return nil, errors.Errorf("no valid share of qualified dealer %s", dealer)
+		}
+
+		share.Add(share, dealerShare)
+		if commitments == nil{
+			commitments = dealerCommitments
+		} else{
+			sum, err := commitments.Add(dealerCommitments)
+			if err != nil{
+				return nil, err
+			}
+			commitments = sum
+		}
+	}
+	if len(qualified) < si.t+1{
+		return nil, errors.Errorf("%d qualified dealers, at least %d are needed", len(qualified), si.t+1)
+	}
+
+	keys := &coin.Keys{
+		T:				si.t,
+		Index:		index,
+		Share:		share,
+		PublicKey:	commitments.PublicKey(),
+		Holders:		make(map[string]coin.Holder, len(si.holders)),
+	}
+	for peerID, holderIndex := range si.holders{
+		keys.Holders[peerID] = coin.Holder{Index: holderIndex, VerificationKey: commitments.Eval(holderIndex)}
+	}
+	if err := keys.Check(m.nodeID); err != nil{
+		return nil, err
+	}
+	return &Result{Keys: keys, Qualified: qualified}, nil
+}
+
+
+//other parts of the node can call this to receive the Results of the sessions this node took part in
+func (m *Manager) SubscribeToMessages() messages.Subscriber{
+	return m.subscribers.Subscribe(messages.DefaultSubscriptionConfig())
+}
+
+//join session, unless this node already did
+func (m *Manager) run(session uint64) runResult{
+	si := m.join(session)
+	if si == nil{
+		return runResult{err: errors.Errorf("dkg session %d is over and was evicted", session)}
+	}
+	return runResult{result: si.result}
+}
+
+//RunDKG starts the key generation session and returns its result. Calling it on a single node
+//is enough: its DEAL makes every other node join the session.
+//if the session is already over, its result is returned right away. If ctx ends first,
+//ctx.Err() is returned and the session keeps going.
+func (m *Manager) RunDKG(ctx context.Context, session uint64) (Result, error){
+	//subscribe before starting, so the result can't be missed
+	sub := m.SubscribeToMessages()
+	defer sub.Close()
+
+	resultC := make(chan runResult, 1)
+	m.runC <- runEvent{session: session, resultC: resultC}
+	run := <-resultC
+	if run.err != nil{
+		return Result{}, run.err
+	}
+	if run.result != nil{
+		return *run.result, run.result.Err
+	}
+
+	msg, err := messages.WaitFor(ctx, sub, func(msg messages.Message) bool{
+		result, ok := msg.(Result)
+		return ok && result.Session == session
+	})
+	switch{
+		case err == nil:
+			result := msg.(Result)
+			if result.Err != nil{
+				m.logger.Error("failed dkg session", zap.Uint64("session", session), zap.Error(result.Err))
+			}
+			return result, result.Err
+		case err == ctx.Err():
+			m.logger.Debug("dkg session not over before deadline", zap.Uint64("session", session))
+		default:
+			m.logger.Error("failed dkg session", zap.Uint64("session", session), zap.Error(err))
+	}
+	return Result{}, err
+}
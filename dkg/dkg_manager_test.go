@@ -0,0 +1,278 @@
+package dkg
+
+import(
+	"context"
+	"crypto/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"distry/aba"
+	"distry/acs"
+	"distry/internal/testnet"
+	"distry/membership"
+	"distry/messages"
+	"distry/rbc0"
+	"distry/ssecret_sharing"
+)
+
+const testPhaseTimeout = 300*time.Millisecond
+
+//create dkg Managers of n nodes with real identity keys, and their own aba Managers.
+//drop is the Drop of their network
+func setupManagers(t *testing.T, n int, drop func(from, to peer.ID, msg messages.Message) bool) ([]*Manager, []*testnet.Node, *testnet.Network){
+	t.Helper()
+	return setupManagersWith(t, n, drop, nil)
+}
+
+//like setupManagers, node i sees the peers of see(i, epoch) if see is not nil
+func setupManagersWith(t *testing.T, n int, drop func(from, to peer.ID, msg messages.Message) bool, see func(i int, epoch membership.Epoch) membership.Epoch) ([]*Manager, []*testnet.Node, *testnet.Network){
+	t.Helper()
+
+	privKeys := make([]crypto.PrivKey, n)
+	network := &testnet.Network{Drop: drop}
+	nodes := make([]*testnet.Node, n)
+	epoch := membership.Epoch{Number: 1, N: n}
+	for i := range nodes{
+		privKey, pubKey, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil{
+			t.Fatal(err)
+		}
+		id, err := peer.IDFromPublicKey(pubKey)
+		if err != nil{
+			t.Fatal(err)
+		}
+		privKeys[i] = privKey
+		nodes[i] = network.AddNode(id)
+		epoch.Peers = append(epoch.Peers, id)
+	}
+	sort.Slice(epoch.Peers, func(i, j int) bool{ return epoch.Peers[i] < epoch.Peers[j] })
+
+	managers := make([]*Manager, n)
+	for i, node := range nodes{
+		membershipManager := testnet.Membership{Epoch: epoch}
+		if see != nil{
+			membershipManager.Epoch = see(i, epoch)
+		}
+		abaManager, err := aba.NewManager(nil, rbc0.DefaultConfig(), aba.HashCoin{}, membershipManager, node)
+		if err != nil{
+			t.Fatal(err)
+		}
+		m, err := NewManager(nil, rbc0.DefaultConfig(), Config{PhaseTimeout: testPhaseTimeout}, privKeys[i], membershipManager, node, node, abaManager)
+		if err != nil{
+			t.Fatal(err)
+		}
+		managers[i] = m
+	}
+	return managers, nodes, network
+}
+
+//the view of the epoch of nodes
+func viewOf(nodes []*testnet.Node) string{
+	epoch := membership.Epoch{Number: 1, N: len(nodes)}
+	for _, node := range nodes{
+		epoch.Peers = append(epoch.Peers, node.ID())
+	}
+	sort.Slice(epoch.Peers, func(i, j int) bool{ return epoch.Peers[i] < epoch.Peers[j] })
+	return acs.View(epoch)
+}
+
+//whether msg is a SHARE, sent directly or published
+func isShare(msg messages.Message) bool{
+	share, ok := messages.Unwrap(msg).(messages.MsgDkg)
+	return ok && share.Type == typeShare
+}
+
+//whether msg is a delivery of stream
+func isStream(msg messages.Message, stream string) bool{
+	delivery, ok := msg.(messages.Rbc0Delivery)
+	if !ok{
+		return false
+	}
+	_, deliveryStream, _, _ := rbc0.ParseProtocolID(delivery.ProtocolID)
+	return deliveryStream == stream
+}
+
+//run session on all managers, check that they agree on the key and that the shares
+//of any t+1 of them interpolate into it
+func run(t *testing.T, managers []*Manager, session uint64) []Result{
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*testPhaseTimeout)
+	defer cancel()
+
+	//starting the session on one node is enough
+	results := make([]Result, len(managers))
+	errs := make(chan error, len(managers))
+	wg := new(sync.WaitGroup)
+	for i, m := range managers{
+		wg.Add(1)
+		go func(i int, m *Manager){
+			defer wg.Done()
+			if i > 0{
+				time.Sleep(testPhaseTimeout / 10)
+			}
+			result, err := m.RunDKG(ctx, session)
+			if err != nil{
+				errs <- err
+				return
+			}
+			results[i] = result
+		}(i, m)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs{
+		t.Fatal(err)
+	}
+
+	keys := results[0].Keys
+	for i, result := range results{
+		if result.Keys.PublicKey.Equal(keys.PublicKey) != 1{
+			t.Fatalf("node %d generated another public key", i)
+		}
+		if !reflect.DeepEqual(result.Qualified, results[0].Qualified){
+			t.Fatalf("node %d qualified %v, node 0 qualified %v", i, result.Qualified, results[0].Qualified)
+		}
+	}
+
+	shares := make([]ssecret_sharing.ScalarShare, keys.T+1)
+	for i := range shares{
+		shares[i] = ssecret_sharing.ScalarShare{Index: results[i].Keys.Index, Value: results[i].Keys.Share}
+	}
+	secret, err := ssecret_sharing.InterpolateScalar(shares)
+	if err != nil{
+		t.Fatal(err)
+	}
+	if new(edwards25519.Point).ScalarBaseMult(secret).Equal(keys.PublicKey) != 1{
+		t.Fatal("shares don't interpolate into the secret key of the public key")
+	}
+	return results
+}
+
+func TestDKG(t *testing.T){
+	managers, _, _ := setupManagers(t, 4, nil)
+	results := run(t, managers, 1)
+	if len(results[0].Qualified) != 4{
+		t.Fatalf("qualified %v, every dealer is correct", results[0].Qualified)
+	}
+}
+
+func TestDKGComplaintAnswered(t *testing.T){
+	//the share of node 3 never reaches node 0, node 0 complains and node 3 reveals it
+	var nodes []*testnet.Node
+	managers, nodes, _ := setupManagers(t, 4, func(from, to peer.ID, msg messages.Message) bool{
+		return isShare(msg) && from == nodes[3].ID() && to == nodes[0].ID()
+	})
+	results := run(t, managers, 1)
+	if len(results[0].Qualified) != 4{
+		t.Fatalf("qualified %v, node 3 answered the complaint", results[0].Qualified)
+	}
+}
+
+func TestDKGBadDealer(t *testing.T){
+	//node 3 sends node 0 no share and does not answer the complaint, it is disqualified
+	var nodes []*testnet.Node
+	managers, nodes, _ := setupManagers(t, 4, func(from, to peer.ID, msg messages.Message) bool{
+		return from == nodes[3].ID() && ((isShare(msg) && to == nodes[0].ID()) || isStream(msg, streamAnswer))
+	})
+	results := run(t, managers, 1)
+	for _, dealer := range results[0].Qualified{
+		if dealer == nodes[3].ID().String(){
+			t.Fatalf("qualified %v, node 3 did not answer the complaint", results[0].Qualified)
+		}
+	}
+	if len(results[0].Qualified) != 3{
+		t.Fatalf("qualified %v, nodes 0 to 2 are correct", results[0].Qualified)
+	}
+}
+
+func TestDKGLateDeal(t *testing.T){
+	//the DEAL of node 3 reaches node 0 only after its answer phase, the other nodes right away.
+	//node 0 does not qualify node 3, the rest do, and the agreement settles it for all of them
+	var nodes []*testnet.Node
+	managers, nodes, _ := setupManagers(t, 4, func(from, to peer.ID, msg messages.Message) bool{
+		if from != nodes[3].ID() || to != nodes[0].ID() || !isStream(msg, streamDeal){
+			return false
+		}
+		time.AfterFunc(4*testPhaseTimeout, func(){ nodes[0].Deliver(msg.(messages.Rbc0Delivery)) })
+		return true
+	})
+	run(t, managers, 1)
+}
+
+func TestDKGAnotherView(t *testing.T){
+	//node 3 sees another peer, nodes 0 to 2 leave it out and generate the key among them
+	managers, nodes, _ := setupManagersWith(t, 4, nil, func(i int, epoch membership.Epoch) membership.Epoch{
+		if i != 3{
+			return epoch
+		}
+		other := membership.Epoch{Number: epoch.Number, N: epoch.N + 1, Peers: append([]peer.ID{"another"}, epoch.Peers...)}
+		sort.Slice(other.Peers, func(i, j int) bool{ return other.Peers[i] < other.Peers[j] })
+		return other
+	})
+	results := run(t, managers[:3], 1)
+	for _, dealer := range results[0].Qualified{
+		if dealer == nodes[3].ID().String(){
+			t.Fatalf("qualified %v, node 3 is of another view", results[0].Qualified)
+		}
+	}
+	if len(results[0].Qualified) != 3{
+		t.Fatalf("qualified %v, nodes 0 to 2 share the view", results[0].Qualified)
+	}
+}
+
+func TestSessionsOnlyJoinedOnDeals(t *testing.T){
+	//a node outside of the epoch deals in session 1, a peer only sends SHAREs in session 2
+	var nodes []*testnet.Node
+	dealt := make(chan uint64, 16)
+	managers, nodes, network := setupManagers(t, 4, func(from, to peer.ID, msg messages.Message) bool{
+		if delivery, ok := msg.(messages.Rbc0Delivery); ok && isStream(msg, streamDeal) && from != "outsider"{
+			_, _, session, _ := rbc0.ParseProtocolID(delivery.ProtocolID)
+			select{
+				case dealt <- session:
+				default:
+			}
+		}
+		return false
+	})
+
+	outsider := network.AddNode("outsider")
+	deal := messages.MsgDkg{SenderID: outsider.ID().String(), Session: 1, Type: typeDeal}
+	payload, err := deal.MarshalToProtobuf().Marshal()
+	if err != nil{
+		t.Fatal(err)
+	}
+	if _, err := outsider.BroadcastInstance(context.Background(), streamDeal, 1, viewOf(nodes) + string(payload)); err != nil{
+		t.Fatal(err)
+	}
+	for _, node := range nodes[1:]{
+		nodes[0].SendOrPublish(node.ID(), &messages.MsgDkg{Session: 2, Type: typeShare, RecipientID: node.ID().String()})
+	}
+
+	select{
+		case session := <-dealt:
+			t.Fatalf("the nodes joined session %d", session)
+		case <-time.After(2*testPhaseTimeout):
+	}
+	run(t, managers, 3)
+}
+
+func TestDKGPublicKeyNotConfirmed(t *testing.T){
+	//no public key reaches another node, so no node keeps its keys
+	managers, _, _ := setupManagers(t, 4, func(from, to peer.ID, msg messages.Message) bool{
+		return from != to && isStream(msg, streamKey)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*testPhaseTimeout)
+	defer cancel()
+	result, err := managers[0].RunDKG(ctx, 1)
+	if err == nil || result.Keys != nil{
+		t.Fatal("keys were kept without n-t holders confirming their public key")
+	}
+}
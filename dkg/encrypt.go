@@ -0,0 +1,99 @@
+package dkg
+
+import(
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"io"
+
+	"filippo.io/edwards25519"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+)
+
+//shares are encrypted to the Ed25519 identity key of their recipient: dealer and recipient
+//derive the same key from a diffie-hellman exchange on edwards25519 and seal the share with AES-GCM
+
+const domainShareKey = "distry dkg share key"
+
+//identityScalar is the scalar of an Ed25519 private key, derived from its seed as in RFC 8032
+func identityScalar(privKey crypto.PrivKey) (*edwards25519.Scalar, error){
+	if privKey.Type() != crypto.Ed25519{
+		return nil, errors.New("dkg needs an Ed25519 identity key")
+	}
+	raw, err := privKey.Raw()
+	if err != nil{
+		return nil, errors.Wrap(err, "reading private key")
+	}
+	h := sha512.Sum512(raw[:32])
+	return edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+}
+
+//identityPoint is the Ed25519 public key embedded in the peer ID
+func identityPoint(peerID string) (*edwards25519.Point, error){
+	id, err := peer.Decode(peerID)
+	if err != nil{
+		return nil, errors.Wrap(err, "decoding peer ID")
+	}
+	pubKey, err := id.ExtractPublicKey()
+	if err != nil{
+		return nil, errors.Wrap(err, "extracting public key from peer ID")
+	}
+	if pubKey.Type() != crypto.Ed25519{
+		return nil, errors.Errorf("%s has no Ed25519 identity key", peerID)
+	}
+	raw, err := pubKey.Raw()
+	if err != nil{
+		return nil, errors.Wrap(err, "reading public key")
+	}
+	return new(edwards25519.Point).SetBytes(raw)
+}
+
+//shareCipher is the cipher of the shares dealer sends recipient in session.
+//the key of the pair is bound to the session, so it differs between sessions.
+func shareCipher(own *edwards25519.Scalar, otherID string, session uint64, dealerID, recipientID string) (cipher.AEAD, error){
+	other, err := identityPoint(otherID)
+	if err != nil{
+		return nil, err
+	}
+	shared := new(edwards25519.Point).ScalarMult(own, other)
+
+	var sessionBytes [8]byte
+	binary.BigEndian.PutUint64(sessionBytes[:], session)
+	h := sha256.New()
+	h.Write([]byte(domainShareKey))
+	h.Write(shared.Bytes())
+	h.Write(sessionBytes[:])
+	h.Write([]byte(dealerID))
+	h.Write([]byte(recipientID))
+
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil{
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+//encryptShare seals share with a random nonce, which is prepended to the ciphertext
+func encryptShare(aead cipher.AEAD, share *edwards25519.Scalar, rand io.Reader) ([]byte, error){
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand, nonce); err != nil{
+		return nil, errors.Wrap(err, "reading randomness")
+	}
+	return aead.Seal(nonce, nonce, share.Bytes(), nil), nil
+}
+
+func decryptShare(aead cipher.AEAD, sealed []byte) (*edwards25519.Scalar, error){
+	if len(sealed) < aead.NonceSize(){
+		return nil, errors.New("encrypted share too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil{
+		return nil, errors.Wrap(err, "decrypting share")
+	}
+	return edwards25519.NewScalar().SetCanonicalBytes(plain)
+}
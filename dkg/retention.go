@@ -0,0 +1,40 @@
+package dkg
+
+import(
+	"time"
+
+	"distry/internal/retention"
+)
+
+//current session counts of the node, served by expvar under /debug/vars
+var metrics = retention.NewMetrics("dkg")
+
+//evict sessions which are over and too old or too many, like rbc0 does with accepted rounds.
+//every session is over after its phases, the agree phase ends when the session would stall.
+//SHAREs of sessions not joined within a phase are dropped, their DEAL did not come.
+func (m *Manager) gc(now time.Time){
+	for session, pending := range m.pending{
+		if retention.Stalled(now, pending.receivedAt, m.dkgCfg.PhaseTimeout){
+			delete(m.pending, session)
+		}
+	}
+
+	evicted := retention.Expired(now, m.cfg.AcceptedMaxAge, m.cfg.AcceptedMaxCount, len(m.doneOrder), func(i int) time.Time{
+		return m.sessionInfoMap[m.doneOrder[i]].doneAt
+	})
+	for _, session := range m.doneOrder[:evicted]{
+		delete(m.sessionInfoMap, session)
+		m.lowWater.Evict("", session)
+	}
+	m.doneOrder = m.doneOrder[evicted:]
+
+	metrics.Add("sessions_evicted_done", int64(evicted))
+	m.updateMetrics()
+}
+
+//publish the current session counts
+func (m *Manager) updateMetrics(){
+	done := len(m.doneOrder)
+	metrics.SetGauge("sessions_active", len(m.sessionInfoMap) - done)
+	metrics.SetGauge("sessions_done", done)
+}
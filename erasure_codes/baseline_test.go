@@ -0,0 +1,308 @@
+package erasure_codes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+)
+
+//the pipeline the package started from (6b3ee39 erasure_codes/io.go and manager.go), kept to
+//benchmark the block pipeline against. every coded byte goes through a chan byte to its writer,
+//and the shards are read back one byte at a time. the prints and the unchecked errors are left out,
+//the GF math is the package's.
+
+type baseline_chunk struct {
+	size int
+	data []byte
+}
+
+type baselineManager struct {
+	k, n       byte
+	mat        [][]byte
+	chunk_size int
+}
+
+func newBaselineManager(k, n byte) *baselineManager {
+	return &baselineManager{k: k, n: n, mat: create_cauchy(k, n, ModeCauchy), chunk_size: 121 * int(n)}
+}
+
+//every row of the cauchy matrix has a goroutine which codes the n-words of a chunk and sends
+//the coded bytes to the writer of its shard
+func (m *baselineManager) encode(c_reader chan baseline_chunk, c_writers []chan byte, padding uint64) {
+	c_data_available := make([]chan struct{}, len(c_writers))
+	var chunk baseline_chunk //read by every row goroutine
+	wg := new(sync.WaitGroup)
+
+	for i := range c_data_available {
+		c_data_available[i] = make(chan struct{})
+		go func(c_data_available chan struct{}, c_writer chan byte, data *baseline_chunk, i int) {
+			c_writer <- byte(i) //index of the row, stored in the shard
+			cauchy_row := m.mat[i]
+			bs := make([]byte, 8)
+			binary.LittleEndian.PutUint64(bs, padding)
+			for _, b := range bs {
+				c_writer <- b
+			}
+
+			for {
+				if _, ok := <-c_data_available; !ok {
+					close(c_writer)
+					return
+				}
+				for z := 0; z < data.size; z += int(m.n) {
+					var encoded_byte byte
+					for ix := range cauchy_row {
+						encoded_byte = add(encoded_byte, mul(cauchy_row[ix], data.data[z+ix]))
+					}
+					c_writer <- encoded_byte
+				}
+				wg.Done() //the chunk may be overwritten
+			}
+		}(c_data_available[i], c_writers[i], &chunk, i)
+	}
+
+	for {
+		var ok bool
+		chunk, ok = <-c_reader
+		if !ok {
+			for _, c := range c_data_available {
+				close(c)
+			}
+			return
+		}
+		wg.Add(len(c_writers))
+		for _, c := range c_data_available {
+			c <- struct{}{}
+		}
+		wg.Wait()
+	}
+}
+
+//encode the file at inpath to inpath_<row>.enc
+func (m *baselineManager) Encode(inpath string) ([]string, error) {
+	shards := int(m.n) + int(m.k)
+	outpaths := make([]string, shards)
+	c_reader := make(chan baseline_chunk)
+	c_writers := make([]chan byte, shards)
+	c_writers_done := make([]chan struct{}, shards)
+	for i := range c_writers {
+		c_writers[i] = make(chan byte, int(m.n))
+		outpaths[i] = fmt.Sprintf("%s_%d.enc", inpath, i)
+		c_writers_done[i] = make(chan struct{})
+		go baselineWriteFile(outpaths[i], m.chunk_size, c_writers[i], c_writers_done[i])
+	}
+
+	fi, err := os.Stat(inpath)
+	if err != nil {
+		return nil, err
+	}
+	padding := uint64(int64(m.n) - fi.Size()%int64(m.n))
+
+	go baselineReadFile(inpath, m.chunk_size, int(padding), c_reader)
+	go m.encode(c_reader, c_writers, padding)
+	for _, c := range c_writers_done {
+		<-c
+	}
+	return outpaths, nil
+}
+
+//decode the n shards at shard_paths to outpath, one n-word at a time
+func (m *baselineManager) Decode(shard_paths []string, outpath string) {
+	c_row_indexes := make(chan int)
+	c_encoded_data := make(chan baseline_chunk)
+	c_writer := make(chan byte, int(m.n))
+	c_writer_done := make(chan struct{})
+	go baselineReadShards(shard_paths, m.chunk_size, c_row_indexes, c_encoded_data)
+	go baselineWriteFile(outpath, m.chunk_size, c_writer, c_writer_done)
+
+	padding := <-c_row_indexes
+	row_indexes := make([]int, 0, m.n)
+	for row_index := range c_row_indexes {
+		row_indexes = append(row_indexes, row_index)
+	}
+	inv := create_inverse(m.mat, row_indexes)
+
+	for chunk := range c_encoded_data {
+		for ix := 0; ix < chunk.size; ix += int(m.n) {
+			for _, b := range decode_word(inv, chunk.data[ix:ix+int(m.n)]) {
+				if padding > 0 {
+					padding--
+					continue
+				}
+				c_writer <- b
+			}
+		}
+	}
+	close(c_writer)
+	<-c_writer_done
+}
+
+//send the file in chunks, the first one starts with padding zeros
+func baselineReadFile(path string, chunk_size, padding int, c chan baseline_chunk) {
+	defer close(c)
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	for first := true; ; first = false {
+		chunk := baseline_chunk{data: make([]byte, chunk_size)}
+		if first {
+			chunk.size, err = file.Read(chunk.data[padding:])
+			chunk.size += padding
+		} else {
+			chunk.size, err = file.Read(chunk.data)
+		}
+		if chunk.size > 0 {
+			c <- chunk
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+//write the bytes of c to the file at path
+func baselineWriteFile(path string, chunk_size int, c chan byte, c_done chan struct{}) {
+	defer close(c_done)
+	file, err := os.Create(path)
+	if err != nil {
+		for range c {
+		}
+		return
+	}
+	defer file.Close()
+
+	buf := make([]byte, chunk_size)
+	ix := 0
+	for b := range c {
+		buf[ix] = b
+		ix++
+		if ix == len(buf) {
+			file.Write(buf)
+			ix = 0
+		}
+	}
+	file.Write(buf[:ix])
+}
+
+//send the padding and the sorted rows of the shards, then their n-words in chunks.
+//the shards are read a byte at a time, one after the other.
+func baselineReadShards(shard_paths []string, chunk_size int, c_row_indexes chan int, c_encoded_data chan baseline_chunk) {
+	defer close(c_encoded_data)
+	row_to_shard := make(map[int]int)
+	files := make([]*os.File, len(shard_paths))
+	for i := range files {
+		var err error
+		if files[i], err = os.Open(shard_paths[i]); err != nil {
+			close(c_row_indexes)
+			return
+		}
+		defer files[i].Close()
+
+		row_index := make([]byte, 1)
+		files[i].Read(row_index)
+		row_to_shard[int(row_index[0])] = i
+		paddingBuf := make([]byte, 8)
+		files[i].Read(paddingBuf)
+		if i == 0 {
+			c_row_indexes <- int(binary.LittleEndian.Uint64(paddingBuf))
+		}
+	}
+
+	rows := make([]int, 0, len(files))
+	for row := range row_to_shard {
+		rows = append(rows, row)
+	}
+	sort.Ints(rows)
+	for _, row := range rows {
+		c_row_indexes <- row
+	}
+	close(c_row_indexes)
+
+	chunk := baseline_chunk{data: make([]byte, chunk_size)}
+	for eof := false; !eof; {
+		for _, row := range rows {
+			n, err := files[row_to_shard[row]].Read(chunk.data[chunk.size : chunk.size+1])
+			if err != nil { //all shards end after the same byte
+				eof = true
+				break
+			}
+			chunk.size += n
+		}
+		if chunk.size == chunk_size || (eof && chunk.size > 0) {
+			c_encoded_data <- chunk
+			chunk = baseline_chunk{data: make([]byte, chunk_size)}
+		}
+	}
+}
+
+//BenchmarkBaselineFile codes the file of BenchmarkFile with the same k and n through the
+//baseline pipeline. it takes many minutes, run it with -benchtime 1x -timeout 2h.
+func BenchmarkBaselineFile(b *testing.B) {
+	if testing.Short() {
+		b.Skip("codes a 2 GiB file")
+	}
+	dir := b.TempDir()
+	path := filepath.Join(dir, "data")
+	if err := writeRandomFile(path, fileBenchSize); err != nil {
+		b.Fatal(err)
+	}
+	m := newBaselineManager(4, 10)
+
+	var shards []string
+	b.Run("encode", func(b *testing.B) {
+		b.SetBytes(fileBenchSize)
+		for i := 0; i < b.N; i++ {
+			var err error
+			if shards, err = m.Encode(path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("decode", func(b *testing.B) {
+		if shards == nil {
+			b.Skip("encode didn't run")
+		}
+		b.SetBytes(fileBenchSize)
+		for i := 0; i < b.N; i++ {
+			m.Decode(shards[2:12], path+".out")
+		}
+		b.StopTimer()
+		if err := sameFiles(path, path+".out"); err != nil {
+			b.Fatal(err)
+		}
+	})
+}
+
+func sameFiles(a, b string) error {
+	fa, err := os.Open(a)
+	if err != nil {
+		return err
+	}
+	defer fa.Close()
+	fb, err := os.Open(b)
+	if err != nil {
+		return err
+	}
+	defer fb.Close()
+
+	bufA, bufB := make([]byte, 1<<20), make([]byte, 1<<20)
+	for off := 0; ; off += len(bufA) {
+		nA, errA := io.ReadFull(fa, bufA)
+		nB, errB := io.ReadFull(fb, bufB)
+		if nA != nB || !bytes.Equal(bufA[:nA], bufB[:nB]) {
+			return fmt.Errorf("%s and %s differ in the MiB at %d", a, b, off)
+		}
+		if errA != nil || errB != nil {
+			return nil
+		}
+	}
+}
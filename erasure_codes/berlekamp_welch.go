@@ -0,0 +1,167 @@
+package erasure_codes
+
+//------------------------------------
+
+//every n-word d of the data defines the polynomial P(x) = sum_j d_j * prod_{l!=j} (x + y_l) of degree < n,
+//where y_j = n+k+j are the columns of the cauchy matrix. Let Q(x) = prod_j (x + y_j).
+//A cauchy row x = i codes d into sum_j d_j / (i + y_j) = P(i) / Q(i).
+//A data row j of ModeSystematic codes d into d_j = P(y_j) / prod_{l!=j} (y_j + y_l).
+//So in both modes the shard of row r holds P(points[r]) / scales[r]: the code is a (generalized)
+//reed-solomon code, and Berlekamp-Welch can find the wrong bytes of a word coded with more than n rows.
+type rsView struct {
+	points, scales []byte
+}
+
+func create_rs_view(k, n byte, mode Mode) rsView {
+	ys := make([]byte, n)
+	for j := range ys {
+		ys[j] = n + k + byte(j)
+	}
+	q := func(x byte, skip int) byte { //prod_{l!=skip} (x + y_l)
+		result := byte(1)
+		for l, y := range ys {
+			if l != skip {
+				result = mul(result, add(x, y))
+			}
+		}
+		return result
+	}
+
+	view := rsView{points: make([]byte, n+k), scales: make([]byte, n+k)}
+	for r := 0; r < int(n+k); r++ {
+		switch {
+			case mode == ModeSystematic && r < int(n):
+				view.points[r], view.scales[r] = ys[r], q(ys[r], r)
+			case mode == ModeSystematic:
+				view.points[r], view.scales[r] = byte(r) - n, q(byte(r) - n, -1)
+			default:
+				view.points[r], view.scales[r] = byte(r), q(byte(r), -1)
+		}
+	}
+	return view
+}
+
+//find the wrong bytes of enc, the bytes of an n-word coded with rows, of which there are at most t.
+//Berlekamp-Welch: with y_i = P(a_i) the received values at the points a_i, find the error locator E
+//(monic, degree t) and N = P*E (degree < n+t) such that N(a_i) = y_i * E(a_i) for every i.
+//That is a linear system in the coefficients of N and E. P = N/E, and the wrong bytes are where P differs.
+//returns the indexes into rows of the wrong bytes, ok is false if there are more than t of them.
+func berlekamp_welch(view rsView, rows []int, enc []byte, n, t int) (bad []int, ok bool) {
+	unknowns := n + 2*t
+	system := make([][]byte, len(rows))
+	ys := make([]byte, len(rows))
+	for i, row := range rows {
+		a := view.points[row]
+		ys[i] = mul(enc[i], view.scales[row])
+
+		//sum_{j<n+t} N_j a^j + sum_{j<t} E_j y a^j = y a^t (subtraction is addition)
+		eq := make([]byte, unknowns+1)
+		pow := byte(1)
+		for j := 0; j < n+t; j++ {
+			if j < t {
+				eq[n+t+j] = mul(ys[i], pow)
+			}
+			if j == t {
+				eq[unknowns] = mul(ys[i], pow)
+			}
+			eq[j] = pow
+			pow = mul(pow, a)
+		}
+		system[i] = eq
+	}
+
+	solution, ok := solve(system, unknowns)
+	if !ok {
+		return nil, false
+	}
+	locator := append(solution[n+t:], 1)
+	p, rem := poly_div(solution[:n+t], locator)
+	for _, c := range rem {
+		if c != 0 {
+			return nil, false
+		}
+	}
+
+	for i, row := range rows {
+		if eval(p, view.points[row]) != ys[i] {
+			bad = append(bad, i)
+		}
+	}
+	if len(bad) > t {
+		return nil, false
+	}
+	return bad, true
+}
+
+//solve the linear system of the augmented matrix mat with the given number of unknowns
+//by gauss-jordan elimination. Free unknowns are 0, ok is false if there is no solution.
+func solve(mat [][]byte, unknowns int) (solution []byte, ok bool) {
+	pivots := make([]int, 0, unknowns)
+	for col := 0; col < unknowns && len(pivots) < len(mat); col++ {
+		r := len(pivots)
+		p := -1
+		for i := r; i < len(mat); i++ {
+			if mat[i][col] != 0 {
+				p = i
+				break
+			}
+		}
+		if p < 0 {
+			continue
+		}
+		mat[r], mat[p] = mat[p], mat[r]
+
+		inv := div(1, mat[r][col])
+		for j := col; j <= unknowns; j++ {
+			mat[r][j] = mul(mat[r][j], inv)
+		}
+		for i := range mat { //eliminate col from every other row
+			if i == r || mat[i][col] == 0 {
+				continue
+			}
+			factor := mat[i][col]
+			for j := col; j <= unknowns; j++ {
+				mat[i][j] = sub(mat[i][j], mul(factor, mat[r][j]))
+			}
+		}
+		pivots = append(pivots, col)
+	}
+
+	for i := len(pivots); i < len(mat); i++ {
+		if mat[i][unknowns] != 0 {
+			return nil, false
+		}
+	}
+	solution = make([]byte, unknowns)
+	for r, col := range pivots {
+		solution[col] = mat[r][unknowns]
+	}
+	return solution, true
+}
+
+//divide the polynomials num by den (coefficients from the lowest degree up), den's leading coefficient is not 0
+func poly_div(num, den []byte) (quot, rem []byte) {
+	rem = append([]byte(nil), num...)
+	deg := len(den) - 1
+	if len(num) <= deg {
+		return nil, rem
+	}
+	quot = make([]byte, len(num)-deg)
+	for i := len(num)-1; i >= deg; i-- {
+		c := div(rem[i], den[deg])
+		quot[i-deg] = c
+		for j := 0; j <= deg; j++ {
+			rem[i-deg+j] = sub(rem[i-deg+j], mul(c, den[j]))
+		}
+	}
+	return quot, rem[:deg]
+}
+
+//evaluate the polynomial p at x by horner's rule
+func eval(p []byte, x byte) byte {
+	var result byte
+	for i := len(p)-1; i >= 0; i-- {
+		result = add(mul(result, x), p[i])
+	}
+	return result
+}
@@ -1,11 +1,12 @@
-package main
+package erasure_codes
 
 //here basic operations on polynomials over the galois field 2^8 are implemented
 
 var prime = 0x11d
 var exp_table = make([]byte, 512)
 var log_table = make([]byte, 256)
-var tables_initialised = false
+//mul_table[a][b] = mul(a, b), for coding whole blocks a byte at a time
+var mul_table [256][256]byte
 
 // calculate bit length
 func length(a int) int {
@@ -37,6 +38,12 @@ func mul_costly(a, b byte) byte {
 }
 
 
+//the tables are filled before any code of the package runs,
+//so encoders and decoders can use them from many goroutines
+func init() {
+	init_tables()
+}
+
 //use generator 2 to init log and exp tables
 func init_tables() {
 	x := byte(1)
@@ -48,31 +55,24 @@ func init_tables() {
 	for i:=255; i<512; i++ {
 		exp_table[i] = exp_table[i-255]
 	}
+	for a:=0; a<256; a++ {
+		for b:=0; b<256; b++ {
+			mul_table[a][b] = mul(byte(a), byte(b))
+		}
+	}
 }
 
 //-----------------------------------------
 
 func add(a, b byte) byte {
-	if ! tables_initialised {
-		init_tables()
-		tables_initialised = true
-	}
 	return a^b
 }
 
 func sub(a, b byte) byte {
-	if ! tables_initialised {
-		init_tables()
-		tables_initialised = true
-	}
 	return a^b
 }
 
 func mul(a, b byte) byte {
-	if ! tables_initialised {
-		init_tables()
-		tables_initialised = true
-	}
 	if a==0 || b==0 {
 		return 0
 	}
@@ -80,10 +80,6 @@ func mul(a, b byte) byte {
 }
 
 func div(a, b byte) byte {
-	if ! tables_initialised {
-		init_tables()
-		tables_initialised = true
-	}
 	if a == 0{
 		return 0
 	} else if b == 0{
@@ -0,0 +1,149 @@
+package erasure_codes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+//------------------------------------
+
+//every shard starts with a header which describes the code and the data it was coded from:
+//
+//	magic		[4]byte	"DSRS"
+//	version	uint8		1
+//	mode		uint8
+//	k			uint8
+//	n			uint8
+//	row		uint8		index of the matrix row which coded the shard
+//	blockSize	uint32	number of coded bytes in every block but the last
+//	length	uint64	length of the data
+//	hash		[32]byte	sha256 of the data
+//	crc		uint32	crc32c of the bytes above
+//
+//integers are little endian. The header is followed by the blocks of the shard,
+//each holding up to blockSize coded bytes followed by their crc32c.
+
+const (
+	shardMagic = "DSRS"
+	shardVersion = 1
+	headerSize = 4 + 5 + 4 + 8 + sha256.Size + 4
+	//before reading any block, Decode and Repair allocate about 2*BlockSize bytes for every shard
+	//they are given and 2*BlockSize*N for the n-words. ReadHeader refuses BlockSize*(N+K) above
+	//this, so n+k shards can't make them allocate more than about 4*maxBlockBytes.
+	//the default block size fits with any code.
+	maxBlockBytes = 32 << 20
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+//Header is the header of a shard
+type Header struct {
+	Mode		Mode
+	K, N		int
+	Row		int
+	BlockSize	int
+	Length	uint64
+	Hash		[sha256.Size]byte
+}
+
+func (h Header) marshal() []byte {
+	buf := make([]byte, headerSize)
+	copy(buf, shardMagic)
+	buf[4] = shardVersion
+	buf[5] = byte(h.Mode)
+	buf[6] = byte(h.K)
+	buf[7] = byte(h.N)
+	buf[8] = byte(h.Row)
+	binary.LittleEndian.PutUint32(buf[9:], uint32(h.BlockSize))
+	binary.LittleEndian.PutUint64(buf[13:], h.Length)
+	copy(buf[21:], h.Hash[:])
+	binary.LittleEndian.PutUint32(buf[headerSize-4:], crc32.Checksum(buf[:headerSize-4], crcTable))
+	return buf
+}
+
+//ReadHeader reads the header of a shard and checks it on its own,
+//Decode checks whether it fits the other shards
+func ReadHeader(r io.Reader) (Header, error) {
+	buf := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return Header{}, errors.Wrapf(ErrInvalidShard, "reading header: %v", err)
+	}
+	if string(buf[:4]) != shardMagic {
+		return Header{}, errors.Wrap(ErrInvalidShard, "not a shard")
+	}
+	if buf[4] != shardVersion {
+		return Header{}, errors.Wrapf(ErrInvalidShard, "unknown shard version %d", buf[4])
+	}
+	if crc32.Checksum(buf[:headerSize-4], crcTable) != binary.LittleEndian.Uint32(buf[headerSize-4:]) {
+		return Header{}, errors.Wrap(ErrInvalidShard, "header checksum mismatch")
+	}
+
+	h := Header{
+		Mode:			Mode(buf[5]),
+		K:				int(buf[6]),
+		N:				int(buf[7]),
+		Row:			int(buf[8]),
+		BlockSize:	int(binary.LittleEndian.Uint32(buf[9:])),
+		Length:		binary.LittleEndian.Uint64(buf[13:]),
+	}
+	copy(h.Hash[:], buf[21:])
+
+	if h.Mode != ModeCauchy && h.Mode != ModeSystematic {
+		return Header{}, errors.Wrapf(ErrInvalidShard, "unknown mode %d", h.Mode)
+	}
+	if h.N < 1 || h.K + 2*h.N > 255 || h.Row >= h.N + h.K {
+		return Header{}, errors.Wrapf(ErrInvalidShard, "invalid code k=%d n=%d row=%d", h.K, h.N, h.Row)
+	}
+	if h.BlockSize < 1 || h.BlockSize > maxBlockBytes/(h.N + h.K) {
+		return Header{}, errors.Wrapf(ErrInvalidShard, "invalid block size %d for %d shards", h.BlockSize, h.N + h.K)
+	}
+	return h, nil
+}
+
+//Words returns the number of n-words the data is coded in, the last one is padded with zeros
+func (h Header) Words() uint64 {
+	return (h.Length + uint64(h.N) - 1) / uint64(h.N)
+}
+
+//Blocks returns the number of blocks of every shard
+func (h Header) Blocks() uint64 {
+	return (h.Words() + uint64(h.BlockSize) - 1) / uint64(h.BlockSize)
+}
+
+//ShardSize returns the size of every shard in bytes, header included
+func (h Header) ShardSize() uint64 {
+	return headerSize + h.Words() + 4*h.Blocks()
+}
+
+//blockLen returns the number of coded bytes in block b
+func (h Header) blockLen(b uint64) int {
+	if rest := h.Words() - b*uint64(h.BlockSize); rest < uint64(h.BlockSize) {
+		return int(rest)
+	}
+	return h.BlockSize
+}
+
+//sameData tells whether h and other are shards of the same data coded by the same code
+func (h Header) sameData(other Header) bool {
+	return h.Mode == other.Mode && h.K == other.K && h.N == other.N &&
+		h.BlockSize == other.BlockSize && h.Length == other.Length && bytes.Equal(h.Hash[:], other.Hash[:])
+}
+
+func appendCRC(block []byte) []byte {
+	var crc [4]byte
+	binary.LittleEndian.PutUint32(crc[:], crc32.Checksum(block, crcTable))
+	return append(block, crc[:]...)
+}
+
+func checkCRC(block []byte) bool {
+	if len(block) < 4 {
+		return false
+	}
+	data, crc := block[:len(block)-4], block[len(block)-4:]
+	return crc32.Checksum(data, crcTable) == binary.LittleEndian.Uint32(crc)
+}
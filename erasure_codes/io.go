@@ -1,8 +1,12 @@
-package main
+package erasure_codes
+
 import (
-	"encoding/binary"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"sort"
 
@@ -10,214 +14,435 @@ import (
 )
 
 //------------------------------------
-var const_CHUNK_SIZE int
-
-type data_chunk struct {
-	size int
-	data []byte
-}
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
 
+//a shard is a Header followed by blocks of coded bytes, every block checked by its crc32c.
+//the data is cut into n-words, the last one padded with zeros. Block b of shard i holds
+//the dot products of row i with the n-words b*blockSize to (b+1)*blockSize.
 
-//open all shards and read their first byte to get the index of the cauchy row that was used
-//to encode them. Sort the indexes asc, then send them back via the c_row_indexes channel.
-//Then sort the shards and read one byte from the first, one from the second.. until all data //is read. Send this data back in chunks via the c_encoded_data channel.
-//(Imagine that the n sorted shards make up a matrix with n rows. The data from this matrix
-//is then read column-wise, from left to right).
-func readShards(shardpaths []string, c_row_indexes chan int, c_encoded_data chan data_chunk){
-	defer close(c_encoded_data)
-	n := len(shardpaths)
-	var err error
+//number of coded bytes in a block of a shard, so a block covers defaultBlockSize n-words of data
+const defaultBlockSize = 1 << 16
 
-	//0->3 means that the first byte of file shardpaths[3] was '0'
-	//ie. that shardpaths[3] was encoded using the 0th row of the encoding cauchy matrix
-	row_to_shardpath := make(map[int]int)
-	file_handles := make([]*os.File, n)
+//Encode reads data from r until io.EOF and writes its n+k shards to shards,
+//shards[i] gets the shard coded with row i of the coding matrix.
+//the header of every shard holds the length and hash of the data, so they are known before
+//the data is coded: if r can seek, it is read twice, otherwise the data is spooled to a temporary file.
+func (e *Encoder) Encode(r io.Reader, shards []io.Writer) error {
+	if len(shards) != e.Shards() {
+		return errors.Wrapf(ErrInvalidParams, "%d writers for %d shards", len(shards), e.Shards())
+	}
+	r, length, hash, cleanup, err := measure(r)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 
-	for i := range file_handles{
-		file_handles[i], err = os.Open(shardpaths[i]) //open file
-		if err != nil {
-			errors.New("napakica v branju fajla, frend")
+	header := Header{Mode: e.mode, K: e.k, N: e.n, BlockSize: e.blockSize, Length: length, Hash: hash}
+	for i, w := range shards {
+		header.Row = i
+		if _, err := w.Write(header.marshal()); err != nil {
+			return errors.Wrapf(err, "writing shard %d", i)
 		}
-		defer file_handles[i].Close()
+	}
 
-		row_index := make([]byte, 1)
-		_, err := file_handles[i].Read(row_index) //read row index that encoded this file
-		if err != nil {
-			fmt.Println(err)
+	p := newWorkerPool(e.workers)
+	defer p.close()
+	mat := e.mats[e.mode]
+	data := make([]byte, e.blockSize*e.n)
+	stripes := make_stripes(e.n, e.blockSize)
+	coded := make_stripes(e.Shards(), e.blockSize)
+	remaining := length
+	for b := uint64(0); b < header.Blocks(); b++ {
+		words := header.blockLen(b)
+		size := uint64(words*e.n)
+		if size > remaining { //the last n-word is padded with zeros
+			size = remaining
+			for i := size; i < uint64(words*e.n); i++ {
+				data[i] = 0
+			}
+		}
+		if _, err := io.ReadFull(r, data[:size]); err != nil {
+			return errors.Wrap(err, "reading data")
 		}
-		row_to_shardpath[int(row_index[0])] = i //store row index in map
+		remaining -= size
 
-		paddingBuf := make([]byte, 8)
-		_, err = file_handles[i].Read(paddingBuf)
-		if err != nil {
-			fmt.Println(err)
+		//every shard gets the dot products of the n-words with its row
+		transpose(data, stripes, words)
+		if e.mode == ModeSystematic { //data shards get their byte of every n-word, their stripe
+			copy(coded, stripes) //coded[i] is stripes[i] for i < n
+			mul_matrix(p, mat[e.n:], stripes, coded[e.n:], words)
+		} else {
+			mul_matrix(p, mat, stripes, coded, words)
 		}
-		padding := binary.LittleEndian.Uint64(paddingBuf)
-		if i == 0 {
-			c_row_indexes <- int(padding)
+		for i, w := range shards {
+			if _, err := w.Write(appendCRC(coded[i][:words])); err != nil {
+				return errors.Wrapf(err, "writing shard %d", i)
+			}
 		}
 	}
+	return nil
+}
 
-	//sort the indexes
-	keys := make([]int, 0, n)
-	for k := range row_to_shardpath { //make array of row indexes
-		keys = append(keys, k)
-	}
-	sort.Ints(keys) //sort the row indexes in asc
+//return a reader of the data of r, along with the length and hash of the data.
+//cleanup removes the temporary file the data of a reader which can't seek was spooled to,
+//files of pipes are io.Seekers too, so a reader whose Seek fails is spooled as well
+func measure(r io.Reader) (data io.Reader, length uint64, hash [sha256.Size]byte, cleanup func(), err error) {
+	cleanup = func() {}
+	h := sha256.New()
 
-	//send sorted indexes back, they will be used to construct the cauchy sub-matrix
-	for _, k := range keys {
-		c_row_indexes <- k
-	}
-	close(c_row_indexes)
-
-	var chunk data_chunk
-	chunk.data = make([]byte, const_CHUNK_SIZE)
-	files_closed := false
-	for {
-		for _, k := range keys { //read one byte before moving to the next shard
-			file := file_handles[row_to_shardpath[k]]
-			readSize, err := file.Read(chunk.data[chunk.size:chunk.size+1])
+	if seeker, ok := r.(io.Seeker); ok {
+		if start, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			n, err := io.Copy(h, r)
 			if err != nil {
-				if err == io.EOF {
-					files_closed = true
-					//break can be used here because all shards are the same size, which means
-					//they all close at the same time (after same amount of bytes read).
-					//chunk.size += readSize
-					if readSize != 0 {
-						fmt.Println("pomoje tole ni kul")
-					}
-					//fmt.Println("zadnji", readSize)
-					break
-				} else {
-					fmt.Println(err)
-					return
-				}
+				return nil, 0, hash, cleanup, errors.Wrap(err, "reading data")
 			}
-			chunk.size += readSize
-		}
-		
-		//it is safe to consider chunk fulness only after every n bytes have been read
-		//because const_CHUNK_SIZE is a multiple of n.
-		if chunk.size >= const_CHUNK_SIZE { 
-			copyChunk := data_chunk{
-				size: chunk.size,
-				data: make([]byte, chunk.size),
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return nil, 0, hash, cleanup, errors.Wrap(err, "seeking data")
 			}
-			copy(copyChunk.data, chunk.data)
-			c_encoded_data <- copyChunk
-			chunk.size = 0
-		}
-		if files_closed{
-			break
+			copy(hash[:], h.Sum(nil))
+			return r, uint64(n), hash, cleanup, nil
 		}
 	}
 
-	if chunk.size > 0 {
-		fmt.Println("posl zadnga")
-		c_encoded_data <- chunk //send the last chunk, even if it's not full
+	spool, err := ioutil.TempFile("", "erasure_codes-")
+	if err != nil {
+		return nil, 0, hash, cleanup, errors.Wrap(err, "creating spool file")
+	}
+	cleanup = func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}
+	n, err := io.Copy(io.MultiWriter(h, spool), r)
+	if err != nil {
+		cleanup()
+		return nil, 0, hash, func() {}, errors.Wrap(err, "spooling data")
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, 0, hash, func() {}, errors.Wrap(err, "seeking spool file")
 	}
+	copy(hash[:], h.Sum(nil))
+	return bufio.NewReader(spool), uint64(n), hash, cleanup, nil
+}
+
+//------------------------------------
 
+//Report tells which shards a decode could not use, shards are identified by their index
+//in the shards passed to the decode
+type Report struct {
+	//map [ SHARD_INDEX -> why the shard was skipped ]
+	Skipped map[int]error
+	//map [ SHARD_INDEX -> number of blocks which failed their crc ]
+	CorruptBlocks map[int]int
+	//map [ SHARD_INDEX -> number of its bytes which were wrong, found by a correcting Decoder ]
+	Corrected map[int]int
 }
 
-func writeFile(path string, c chan byte, c_done chan struct{}) {
-	totalWrite := 0
-	file, err := os.Create(path)
-	if err != nil {
-		fmt.Println(err)
+func newReport() Report {
+	return Report{Skipped: make(map[int]error), CorruptBlocks: make(map[int]int), Corrected: make(map[int]int)}
+}
+
+//Bad returns the sorted indexes of the shards the report names, a shard with corrupt blocks
+//and corrected bytes is named once
+func (r Report) Bad() []int {
+	bad := make(map[int]struct{})
+	for i := range r.Skipped {
+		bad[i] = struct{}{}
+	}
+	for i := range r.CorruptBlocks {
+		bad[i] = struct{}{}
+	}
+	for i := range r.Corrected {
+		bad[i] = struct{}{}
 	}
-	defer file.Close()
-	defer close(c_done)
+	indexes := make([]int, 0, len(bad))
+	for i := range bad {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
 
-	buf := make([]byte, const_CHUNK_SIZE)
-	ix := 0
-	for b := range c { //receive byte, write to file
-		buf[ix] = b
-		ix++
+//a shard being decoded
+type shardReader struct {
+	index int
+	header Header
+	r io.Reader
+	next uint64 //the block r is at
+	buf []byte
+	broken bool
+}
 
-		if ix >= len(buf) {
-			ix = 0
-			totalWrite += len(buf)
-			_, err := file.Write(buf)
-			if err != nil {
-				fmt.Println(err)
-			}
+//read block b of the shard including its crc, skipping the blocks before it
+func (s *shardReader) readBlock(b uint64) ([]byte, error) {
+	for ; s.next < b; s.next++ {
+		if _, err := io.CopyN(ioutil.Discard, s.r, int64(s.header.blockLen(s.next) + 4)); err != nil {
+			return nil, errors.Wrapf(ErrInvalidShard, "skipping block %d: %v", s.next, err)
 		}
 	}
-
-	if ix > 0 {
-		totalWrite += ix
-		_, err := file.Write(buf[:ix])
-		if err != nil {
-			fmt.Println(err)
-		}
+	block := s.buf[:s.header.blockLen(b) + 4]
+	if _, err := io.ReadFull(s.r, block); err != nil {
+		return nil, errors.Wrapf(ErrInvalidShard, "reading block %d: %v", b, err)
 	}
+	s.next++
+	return block, nil
+}
 
+//Decode reads the shards and writes the decoded data to w.
+//any n valid shards suffice, in any order. Shards which are corrupt or belong to other data
+//are skipped, and a block which fails its crc is taken from another shard.
+func (d *Decoder) Decode(shards []io.Reader, w io.Writer) error {
+	_, err := d.DecodeReport(shards, w)
+	return err
 }
 
-//read bytes from file, send them to channel c
-func readFile(path string, c chan data_chunk, n byte) {
-	file, err := os.Open(path)
-	defer file.Close()
+//DecodeReport is Decode which also reports the shards it could not use
+func (d *Decoder) DecodeReport(shards []io.Reader, w io.Writer) (Report, error) {
+	report := newReport()
+	readers, err := d.selectShards(shards, report)
 	if err != nil {
-		errors.New("napakica v branju fajla frent")
+		return report, err
 	}
+	header := readers[0].header
 
-	// Get file size
-	stat, err := file.Stat()
-	if err != nil {
-		errors.New("napakica v statu frent")
-	}
-	fmt.Println("fsize", stat.Size())
-	fSize := stat.Size()
+	h := sha256.New()
+	out := io.MultiWriter(w, h)
+	p := newWorkerPool(d.workers)
+	defer p.close()
+	mat := d.mats[header.Mode]
+	decoders := newDecodeMatrices(mat, header.Mode)
+	blocks := make([][]byte, len(readers))
+	rows := make([]int, len(readers))
+	indexes := make([]int, len(readers)) //of the shards of the chosen blocks
+	stripes := make_stripes(d.n, header.BlockSize)
+	coded := make_stripes(len(readers), header.BlockSize) //the blocks coded again when correcting
+	enc := make([]byte, len(readers))
+	decoded := make([]byte, header.BlockSize*d.n)
+	remaining := header.Length
 
-	// Some padding is necessary.
-	padding := int(int64(n) - (fSize % int64(n)))
-	fmt.Println("padding neccessary", padding)
-	if padding > 0 {
-		var chunk data_chunk
-		chunk.data = make([]byte, const_CHUNK_SIZE)
+	for b := uint64(0); b < header.Blocks(); b++ {
+		//take the block from the first n shards, by row, which have it intact.
+		//correcting takes it from every shard which has it intact.
+		max := d.n
+		if d.correct {
+			max = len(readers)
+		}
+		chosen := readBlocks(readers, b, max, report, blocks, rows, indexes)
+		if chosen < d.n {
+			return report, errors.Wrapf(ErrTooFewShards, "block %d is intact in %d shards, need %d", b, chosen, d.n)
+		}
 
-		chunk.size, err = file.Read(chunk.data[padding:])
-		chunk.size += padding
-		if err != nil {
-			if err == io.EOF{
-				if chunk.size > 0{
-					c <- chunk
+		words := header.blockLen(b)
+		mul_matrix(p, decoders.get(rows[:d.n]), blocks[:d.n], stripes, words)
+
+		if d.correct && chosen > d.n { //code the n-words again with the other rows, they must match
+			mul_matrix(p, create_submatrix(mat, rows[d.n:chosen], seq(d.n)), stripes, coded, words)
+			for word := 0; word < words; word++ {
+				consistent := true
+				for i := d.n; i < chosen; i++ {
+					if coded[i-d.n][word] != blocks[i][word] {
+						consistent = false
+						break
+					}
+				}
+				if consistent {
+					continue
+				}
+
+				for i := 0; i < chosen; i++ {
+					enc[i] = blocks[i][word]
+				}
+				data, bad, err := d.correctWord(decoders, d.views[header.Mode], rows[:chosen], enc[:chosen])
+				if err != nil {
+					return report, errors.Wrapf(err, "block %d word %d", b, word)
+				}
+				for _, i := range bad {
+					report.Corrected[indexes[i]]++
+				}
+				for j := range stripes {
+					stripes[j][word] = data[j]
 				}
-				close(c)
-				return
-			} else{
-				fmt.Println(err)
 			}
 		}
-		c <- chunk
+
+		decoded = decoded[:words*d.n]
+		interleave(stripes, decoded, words)
+		if uint64(len(decoded)) > remaining { //drop the padding of the last n-word
+			decoded = decoded[:remaining]
+		}
+		if _, err := out.Write(decoded); err != nil {
+			return report, errors.Wrap(err, "writing data")
+		}
+		remaining -= uint64(len(decoded))
 	}
 
-	for {
-		var chunk data_chunk
-		chunk.data = make([]byte, const_CHUNK_SIZE)
+	if !bytes.Equal(h.Sum(nil), header.Hash[:]) {
+		return report, errors.Wrap(ErrCorrupt, "decoded data does not match the hash in the shards")
+	}
+	return report, nil
+}
 
-		chunk.size, err = file.Read(chunk.data)
+//read block b from the shards, in order, until max of them had it intact. The blocks, rows of their shards
+//and indexes of their shards go to blocks, rows and indexes, returns how many there are.
+func readBlocks(readers []*shardReader, b uint64, max int, report Report, blocks [][]byte, rows, indexes []int) int {
+	chosen := 0
+	for _, s := range readers {
+		if chosen == max {
+			break
+		}
+		if s.broken {
+			continue
+		}
+		block, err := s.readBlock(b)
 		if err != nil {
-			if err == io.EOF{
-				if chunk.size > 0{
-					c <- chunk
-				}
-				close(c)
+			s.broken = true
+			report.Skipped[s.index] = err
+			continue
+		}
+		if !checkCRC(block) {
+			report.CorruptBlocks[s.index]++
+			continue
+		}
+		blocks[chosen], rows[chosen], indexes[chosen] = block, s.header.Row, s.index
+		chosen++
+	}
+	return chosen
+}
+
+//read the header of every shard and keep the shards of the data most shards belong to,
+//one per row, sorted by row. in ModeSystematic that prefers the data shards.
+func (m *Manager) selectShards(shards []io.Reader, report Report) ([]*shardReader, error) {
+	groups := make([][]*shardReader, 0, 1)
+	for i, r := range shards {
+		header, err := ReadHeader(r)
+		if err != nil {
+			report.Skipped[i] = err
+			continue
+		}
+		if header.K != m.k || header.N != m.n {
+			report.Skipped[i] = errors.Wrapf(ErrInvalidShard, "coded with k=%d n=%d", header.K, header.N)
+			continue
+		}
+
+		s := &shardReader{index: i, header: header, r: r, buf: make([]byte, header.BlockSize + 4)}
+		joined := false
+		for g, group := range groups {
+			if group[0].header.sameData(header) {
+				groups[g] = append(group, s)
+				joined = true
 				break
-			} else{
-				fmt.Println(err)
 			}
 		}
-		c <- chunk
+		if !joined {
+			groups = append(groups, []*shardReader{s})
+		}
+	}
+	if len(groups) == 0 {
+		return nil, errors.Wrap(ErrTooFewShards, "no valid shards")
+	}
+
+	largest := 0
+	for g, group := range groups {
+		if len(group) > len(groups[largest]) {
+			largest = g
+		}
 	}
+	for g, group := range groups {
+		if g == largest {
+			continue
+		}
+		for _, s := range group {
+			report.Skipped[s.index] = errors.Wrapf(ErrInvalidShard, "shard of other data than shard %d", groups[largest][0].index)
+		}
+	}
+
+	readers := make([]*shardReader, 0, len(groups[largest]))
+	readerOfRow := make(map[int]*shardReader)
+	for _, s := range groups[largest] {
+		if other, exists := readerOfRow[s.header.Row]; exists {
+			report.Skipped[s.index] = errors.Wrapf(ErrInvalidShard, "same row as shard %d", other.index)
+			continue
+		}
+		readerOfRow[s.header.Row] = s
+		readers = append(readers, s)
+	}
+	sort.Slice(readers, func(i, j int) bool { return readers[i].header.Row < readers[j].header.Row })
+
+	if len(readers) < m.n {
+		return nil, errors.Wrapf(ErrTooFewShards, "%d valid shards, need %d", len(readers), m.n)
+	}
+	return readers, nil
+}
+
+//find the wrong bytes of enc, the n-word coded with rows (sorted asc), and decode the n-word from the others.
+//Berlekamp-Welch finds up to (len(rows)-n)/2 wrong bytes. returns the indexes into rows of the wrong bytes.
+func (d *Decoder) correctWord(decoders *decodeMatrices, view rsView, rows []int, enc []byte) ([]byte, []int, error) {
+	bad, ok := berlekamp_welch(view, rows, enc, d.n, (len(rows) - d.n)/2)
+	if !ok {
+		return nil, nil, errors.Wrapf(ErrCorrupt, "more than %d of %d shards are wrong", (len(rows) - d.n)/2, len(rows))
+	}
+	goodRows, goodEnc := make([]int, 0, d.n), make([]byte, 0, d.n)
+	for i := range rows {
+		if len(goodRows) == d.n {
+			break
+		}
+		if containsInt(bad, i) {
+			continue
+		}
+		goodRows, goodEnc = append(goodRows, rows[i]), append(goodEnc, enc[i])
+	}
+
+	word := make([]byte, d.n)
+	for j, row := range decoders.get(goodRows) {
+		word[j] = dot(row, goodEnc)
+	}
+	return word, bad, nil
+}
+
+func containsInt(list []int, x int) bool {
+	for _, y := range list {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
+
+//0, 1, ..., n-1
+func seq(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+//matrices decoding the n-words coded with sets of rows, created as they're needed
+type decodeMatrices struct {
+	mat [][]byte
+	mode Mode
+	//map [ ROWS -> decode matrix ]
+	matrices map[string][][]byte
 }
 
+func newDecodeMatrices(mat [][]byte, mode Mode) *decodeMatrices {
+	return &decodeMatrices{mat: mat, mode: mode, matrices: make(map[string][][]byte)}
+}
+
+//rows are sorted asc
+func (dm *decodeMatrices) get(rows []int) [][]byte {
+	key := fmt.Sprint(rows)
+	dec, exists := dm.matrices[key]
+	if !exists {
+		dec = create_decode_matrix(dm.mat, dm.mode, append([]int(nil), rows...))
+		dm.matrices[key] = dec
+	}
+	return dec
+}
+
+func dot(row, word []byte) byte {
+	var result byte
+	for i := range row {
+		result = add(result, mul(row[i], word[i]))
+	}
+	return result
+}
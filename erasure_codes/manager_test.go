@@ -0,0 +1,536 @@
+package erasure_codes
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	for _, mode := range []Mode{ModeCauchy, ModeSystematic} {
+		testEncodeDecode(t, mode)
+	}
+}
+
+func testEncodeDecode(t *testing.T, mode Mode) {
+	rnd := rand.New(rand.NewSource(3))
+	for _, tc := range []struct{ k, n, size, blockSize int }{
+		{3, 7, 0, 0},
+		{3, 7, 1, 0},
+		{3, 7, 6, 0},
+		{3, 7, 7, 0},
+		{3, 7, 100000, 0},
+		{0, 1, 33, 0},
+		{2, 4, 16*4, 16},
+		{2, 4, 16*4 - 1, 16},
+		{5, 10, 16*10 + 3, 16},
+		{5, 10, 1000, 7},
+	} {
+		enc, err := NewModeEncoder(tc.k, tc.n, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.blockSize > 0 {
+			enc.blockSize = tc.blockSize
+		}
+		enc.SetWorkers(1 + tc.size%3) //more workers than cpus still code the same shards
+		dec, err := NewDecoder(tc.k, tc.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dec.SetWorkers(3)
+
+		data := make([]byte, tc.size)
+		rnd.Read(data)
+		shards, err := enc.EncodeBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(shards) != tc.k+tc.n {
+			t.Fatalf("k=%d n=%d: got %d shards", tc.k, tc.n, len(shards))
+		}
+		if size := enc.ShardSize(uint64(tc.size)); uint64(len(shards[0])) != size {
+			t.Fatalf("k=%d n=%d size=%d: shard of %d bytes, expected %d", tc.k, tc.n, tc.size, len(shards[0]), size)
+		}
+
+		//decode from a few random subsets of n shards, with the rest missing
+		for try := 0; try < 4; try++ {
+			subset := make([][]byte, len(shards))
+			for _, i := range rnd.Perm(len(shards))[:tc.n] {
+				subset[i] = shards[i]
+			}
+			decoded, err := dec.DecodeBytes(subset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(decoded, data) {
+				t.Fatalf("%s k=%d n=%d size=%d: decoded data differs", mode, tc.k, tc.n, tc.size)
+			}
+		}
+	}
+}
+
+func TestEncodeUnseekable(t *testing.T) {
+	enc, err := NewEncoder(3, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc.blockSize = 16
+	data := make([]byte, 16*7*3 + 5)
+	rand.New(rand.NewSource(5)).Read(data)
+	expected, err := enc.EncodeBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//a reader which can't seek, and a pipe, whose Seek fails
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		pw.Write(data)
+		pw.Close()
+	}()
+	defer pr.Close()
+	for _, r := range []io.Reader{struct{ io.Reader }{bytes.NewReader(data)}, pr} {
+		buffers := make([]*bytes.Buffer, enc.Shards())
+		writers := make([]io.Writer, enc.Shards())
+		for i := range buffers {
+			buffers[i] = new(bytes.Buffer)
+			writers[i] = buffers[i]
+		}
+		if err := enc.Encode(r, writers); err != nil {
+			t.Fatal(err)
+		}
+		for i := range buffers {
+			if !bytes.Equal(buffers[i].Bytes(), expected[i]) {
+				t.Fatalf("%T: shard %d differs from the one of EncodeBytes", r, i)
+			}
+		}
+	}
+}
+
+func TestSystematicDataShards(t *testing.T) {
+	enc, _ := NewModeEncoder(3, 4, ModeSystematic)
+	data := []byte("the first n shards hold the data")
+	shards, err := enc.EncodeBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range data {
+		if shard := shards[i % 4]; shard[headerSize + i/4] != b {
+			t.Fatalf("byte %d of the data is not in data shard %d", i, i % 4)
+		}
+	}
+
+	//the k parity shards are too few on their own, a single data shard makes up for the rest
+	dec, _ := NewDecoder(3, 4)
+	if _, err := dec.DecodeBytes(shards[4:]); errors.Cause(err) != ErrTooFewShards {
+		t.Fatalf("expected ErrTooFewShards, got %v", err)
+	}
+	decoded, err := dec.DecodeBytes([][]byte{shards[1], shards[4], shards[5], shards[6]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Fatal("decoded data differs")
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, err := NewEncoder(3, 200); errors.Cause(err) != ErrInvalidParams {
+		t.Fatalf("expected ErrInvalidParams, got %v", err)
+	}
+
+	enc, _ := NewEncoder(2, 3)
+	dec, _ := NewDecoder(2, 3)
+	shards, err := enc.EncodeBytes([]byte("some data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//the same shard twice counts once
+	if _, err := dec.DecodeBytes([][]byte{shards[0], shards[1], shards[1]}); errors.Cause(err) != ErrTooFewShards {
+		t.Fatalf("expected ErrTooFewShards, got %v", err)
+	}
+	truncated := shards[2][:len(shards[2])-1]
+	if _, err := dec.DecodeBytes([][]byte{shards[0], shards[1], truncated}); errors.Cause(err) != ErrTooFewShards {
+		t.Fatalf("expected ErrTooFewShards, got %v", err)
+	}
+
+	//a header announcing huge blocks is refused before they are allocated
+	header, err := ReadHeader(bytes.NewReader(shards[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.BlockSize = maxBlockBytes/5 + 1 //the blocks of all 5 shards
+	if _, err := ReadHeader(bytes.NewReader(header.marshal())); errors.Cause(err) != ErrInvalidShard {
+		t.Fatalf("expected ErrInvalidShard, got %v", err)
+	}
+	//the widest code accepts the default block size
+	header.K, header.N, header.Row, header.BlockSize = 253, 1, 0, defaultBlockSize
+	if _, err := ReadHeader(bytes.NewReader(header.marshal())); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDecodeSkipsBadShards(t *testing.T) {
+	enc, _ := NewEncoder(2, 3)
+	enc.blockSize = 8
+	dec, _ := NewDecoder(2, 3)
+	rnd := rand.New(rand.NewSource(5))
+	data := make([]byte, 100)
+	rnd.Read(data)
+	shards, err := enc.EncodeBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := enc.EncodeBytes([]byte("other data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corruptHeader := append([]byte(nil), shards[0]...)
+	corruptHeader[8] ^= 1
+	corruptBlock := append([]byte(nil), shards[1]...)
+	corruptBlock[headerSize + 1] ^= 1 //in the first block
+	truncated := shards[3][:headerSize + 20] //the second block is cut short
+
+	readers := []io.Reader{
+		bytes.NewReader(corruptHeader),
+		bytes.NewReader(corruptBlock),
+		bytes.NewReader(other[2]),
+		bytes.NewReader(truncated),
+		bytes.NewReader(shards[2]),
+		bytes.NewReader(shards[4]),
+	}
+	out := new(bytes.Buffer)
+	report, err := dec.DecodeReport(readers, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), data) {
+		t.Fatal("decoded data differs")
+	}
+	for _, i := range []int{0, 2, 3} {
+		if errors.Cause(report.Skipped[i]) != ErrInvalidShard {
+			t.Fatalf("expected shard %d to be skipped, got %v", i, report.Skipped[i])
+		}
+	}
+	if report.CorruptBlocks[1] != 1 || len(report.Skipped) != 3 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	//too many shards of the data are bad
+	if _, err := dec.DecodeBytes([][]byte{corruptHeader, corruptBlock, shards[2], truncated}); errors.Cause(err) != ErrTooFewShards {
+		t.Fatalf("expected ErrTooFewShards, got %v", err)
+	}
+}
+
+func TestReportBad(t *testing.T) {
+	report := newReport()
+	report.Skipped[4] = ErrInvalidShard
+	report.CorruptBlocks[1] = 2
+	report.Corrected[1] = 5
+	report.Corrected[0] = 1
+	if bad := report.Bad(); fmt.Sprint(bad) != "[0 1 4]" {
+		t.Fatalf("bad shards %v, expected [0 1 4]", bad)
+	}
+}
+
+func TestCorrectingDecode(t *testing.T) {
+	for _, mode := range []Mode{ModeCauchy, ModeSystematic} {
+		testCorrectingDecode(t, mode)
+	}
+}
+
+func testCorrectingDecode(t *testing.T, mode Mode) {
+	enc, _ := NewModeEncoder(4, 3, mode)
+	enc.blockSize = 8
+	dec, _ := NewCorrectingDecoder(4, 3)
+	dec.SetWorkers(2)
+	rnd := rand.New(rand.NewSource(7))
+	data := make([]byte, 100)
+	rnd.Read(data)
+	shards, err := enc.EncodeBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//change coded byte pos of a shard and fix the crc of its block, so only correcting finds it
+	tamper := func(shard []byte, pos int) []byte {
+		shard = append([]byte(nil), shard...)
+		block := shard[headerSize + pos/8*12:][:8]
+		block[pos%8] ^= byte(1 + rnd.Intn(255))
+		appendCRC(block)
+		return shard
+	}
+
+	//7 shards correct up to 2 wrong bytes per word
+	bad := append([][]byte(nil), shards...)
+	bad[0] = tamper(bad[0], 3)
+	bad[4] = tamper(tamper(bad[4], 3), 9)
+	bad[6] = tamper(bad[6], 9)
+	readers := make([]io.Reader, len(bad))
+	for i := range bad {
+		readers[i] = bytes.NewReader(bad[i])
+	}
+	out := new(bytes.Buffer)
+	report, err := dec.DecodeReport(readers, out)
+	if err != nil {
+		t.Fatalf("%s: %v", mode, err)
+	}
+	if !bytes.Equal(out.Bytes(), data) {
+		t.Fatalf("%s: decoded data differs", mode)
+	}
+	if len(report.Corrected) != 3 || report.Corrected[0] != 1 || report.Corrected[4] != 2 || report.Corrected[6] != 1 {
+		t.Fatalf("%s: unexpected corrections %v", mode, report.Corrected)
+	}
+
+	//a decoder which trusts its shards only notices the hash
+	trusting, _ := NewDecoder(4, 3)
+	if _, err := trusting.DecodeBytes(bad); errors.Cause(err) != ErrCorrupt {
+		t.Fatalf("%s: expected ErrCorrupt, got %v", mode, err)
+	}
+
+	//without shard 1, 6 shards correct up to 1 wrong byte per word
+	if _, err := dec.DecodeBytes([][]byte{bad[0], bad[2], bad[3], bad[4], bad[5], bad[6]}); errors.Cause(err) != ErrCorrupt {
+		t.Fatalf("%s: expected ErrCorrupt, got %v", mode, err)
+	}
+	decoded, err := dec.DecodeBytes([][]byte{shards[0], shards[2], shards[3], bad[4], shards[5], shards[6]})
+	if err != nil || !bytes.Equal(decoded, data) {
+		t.Fatalf("%s: decoding with one wrong shard failed: %v", mode, err)
+	}
+}
+
+func TestRepair(t *testing.T) {
+	for _, mode := range []Mode{ModeCauchy, ModeSystematic} {
+		enc, _ := NewModeEncoder(3, 4, mode)
+		enc.blockSize = 16
+		rnd := rand.New(rand.NewSource(11))
+		data := make([]byte, 1000)
+		rnd.Read(data)
+		shards, err := enc.EncodeBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		//the repaired shards are the lost ones, byte for byte
+		missing := []int{5, 0, 2}
+		survivors := [][]byte{nil, shards[1], nil, shards[3], shards[4], nil, shards[6]}
+		repaired, err := enc.RepairBytes(survivors, missing)
+		if err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+		for i, row := range missing {
+			if !bytes.Equal(repaired[i], shards[row]) {
+				t.Fatalf("%s: repaired shard %d differs", mode, row)
+			}
+		}
+
+		if _, err := enc.RepairBytes(survivors[1:4], missing); errors.Cause(err) != ErrTooFewShards {
+			t.Fatalf("%s: expected ErrTooFewShards, got %v", mode, err)
+		}
+		if _, err := enc.RepairBytes(survivors, []int{7}); errors.Cause(err) != ErrInvalidParams {
+			t.Fatalf("%s: expected ErrInvalidParams, got %v", mode, err)
+		}
+	}
+}
+
+func TestUnitRows(t *testing.T) {
+	for _, c := range []struct {
+		row []byte
+		unit int
+	}{
+		{[]byte{0, 1, 0}, 1},
+		{[]byte{1, 0, 0}, 0},
+		{[]byte{0, 1, 1}, -1},
+		{[]byte{0, 2, 0}, -1},
+		{[]byte{0, 0, 0}, -1},
+	} {
+		if unit := unit_row(c.row); unit != c.unit {
+			t.Fatalf("unit_row(%v) = %d, expected %d", c.row, unit, c.unit)
+		}
+	}
+
+	//the data shards at hand are decoded by unit rows of the decode matrix,
+	//data j is shard unit of the rows 0, 2 and 4
+	m, _ := NewManager(2, 3)
+	mat := newDecodeMatrices(m.mats[ModeSystematic], ModeSystematic).get([]int{0, 2, 4})
+	for r, unit := range []int{0, -1, 1} {
+		if unit_row(mat[r]) != unit {
+			t.Fatalf("row %d of the decode matrix: %v", r, mat[r])
+		}
+	}
+}
+
+const (
+	benchSize = 32 << 20
+	fileBenchSize = 2 << 30
+)
+
+func BenchmarkEncode(b *testing.B) {
+	for _, mode := range []Mode{ModeCauchy, ModeSystematic} {
+		b.Run(mode.String(), func(b *testing.B) {
+			enc, _ := NewModeEncoder(4, 10, mode)
+			data := make([]byte, benchSize)
+			rand.New(rand.NewSource(1)).Read(data)
+			writers := make([]io.Writer, enc.Shards())
+			for i := range writers {
+				writers[i] = ioutil.Discard
+			}
+
+			b.SetBytes(benchSize)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := enc.Encode(bytes.NewReader(data), writers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	for _, mode := range []Mode{ModeCauchy, ModeSystematic} {
+		b.Run(mode.String(), func(b *testing.B) {
+			enc, _ := NewModeEncoder(4, 10, mode)
+			dec, _ := NewDecoder(4, 10)
+			data := make([]byte, benchSize)
+			rand.New(rand.NewSource(1)).Read(data)
+			shards, err := enc.EncodeBytes(data)
+			if err != nil {
+				b.Fatal(err)
+			}
+			shards = shards[2:12] //two data shards are missing in ModeSystematic
+
+			b.SetBytes(benchSize)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				readers := make([]io.Reader, len(shards))
+				for j := range shards {
+					readers[j] = bytes.NewReader(shards[j])
+				}
+				if err := dec.Decode(readers, ioutil.Discard); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+//BenchmarkFile codes a 2 GiB file on disk through buffered files, like distry-ec does,
+//compare it with BenchmarkBaselineFile. it is skipped with -short, run it with -benchtime 1x.
+func BenchmarkFile(b *testing.B) {
+	if testing.Short() {
+		b.Skip("codes a 2 GiB file")
+	}
+	dir := b.TempDir()
+	path := filepath.Join(dir, "data")
+	if err := writeRandomFile(path, fileBenchSize); err != nil {
+		b.Fatal(err)
+	}
+	enc, _ := NewEncoder(4, 10)
+	dec, _ := NewDecoder(4, 10)
+
+	b.Run("encode", func(b *testing.B) {
+		b.SetBytes(fileBenchSize)
+		for i := 0; i < b.N; i++ {
+			if err := encodeFile(enc, path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("decode", func(b *testing.B) {
+		if err := encodeFile(enc, path); err != nil {
+			b.Fatal(err)
+		}
+		b.SetBytes(fileBenchSize)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := decodeFile(dec, path, 2, 12); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func writeRandomFile(path string, size int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	buf := make([]byte, 1<<20)
+	rng := rand.New(rand.NewSource(1))
+	for ; size > 0; size -= len(buf) {
+		rng.Read(buf)
+		if _, err := f.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//code the file at path to path.<row>.shard
+func encodeFile(enc *Encoder, path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	files := make([]*os.File, enc.Shards())
+	bufs := make([]*bufio.Writer, len(files))
+	writers := make([]io.Writer, len(files))
+	for i := range files {
+		if files[i], err = os.Create(fmt.Sprintf("%s.%d.shard", path, i)); err != nil {
+			return err
+		}
+		defer files[i].Close()
+		bufs[i] = bufio.NewWriterSize(files[i], 1<<20)
+		writers[i] = bufs[i]
+	}
+	if err := enc.Encode(in, writers); err != nil {
+		return err
+	}
+	for _, buf := range bufs {
+		if err := buf.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//decode the shards of rows from to to-1 of path to path.out
+func decodeFile(dec *Decoder, path string, from, to int) error {
+	readers := make([]io.Reader, 0, to-from)
+	for i := from; i < to; i++ {
+		f, err := os.Open(fmt.Sprintf("%s.%d.shard", path, i))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		readers = append(readers, bufio.NewReaderSize(f, 1<<20))
+	}
+
+	out, err := os.Create(path + ".out")
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	buf := bufio.NewWriterSize(out, 1<<20)
+	if err := dec.Decode(readers, buf); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
@@ -0,0 +1,141 @@
+package erasure_codes
+
+import (
+	"encoding/binary"
+	"sync"
+)
+
+//------------------------------------
+
+//blocks are coded as a whole rather than an n-word at a time. The n-words of a block of data are
+//transposed into n stripes, stripe j holding byte j of every n-word. A shard's block is then a
+//row of the coding matrix times the stripes, and decoding is the decode matrix of the rows at hand
+//times the shards' blocks. Every row is a task for a bounded pool of workers.
+
+//workerPool runs tasks on a bounded number of goroutines
+type workerPool struct {
+	workers int
+	tasks chan func()
+}
+
+func newWorkerPool(workers int) *workerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &workerPool{workers: workers, tasks: make(chan func())}
+	for i := 1; i < workers; i++ { //the goroutine calling run is a worker too
+		go func() {
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+	return p
+}
+
+//run task(0) to task(n-1) and wait for them to finish
+func (p *workerPool) run(n int, task func(i int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		i := i
+		f := func() {
+			task(i)
+			wg.Done()
+		}
+		select {
+			case p.tasks <- f:
+			default: //every worker is busy
+				f()
+		}
+	}
+	wg.Wait()
+}
+
+func (p *workerPool) close() {
+	close(p.tasks)
+}
+
+//------------------------------------
+
+func make_stripes(count, size int) [][]byte {
+	stripes := make([][]byte, count)
+	for i := range stripes {
+		stripes[i] = make([]byte, size, size+4) //room for the crc
+	}
+	return stripes
+}
+
+//stripes[j][w] = data[w*n+j] for the first words n-words of data
+func transpose(data []byte, stripes [][]byte, words int) {
+	n := len(stripes)
+	for j, stripe := range stripes {
+		stripe = stripe[:words]
+		for w := range stripe {
+			stripe[w] = data[w*n+j]
+		}
+	}
+}
+
+//data[w*n+j] = stripes[j][w] for the first words n-words of data
+func interleave(stripes [][]byte, data []byte, words int) {
+	n := len(stripes)
+	for j, stripe := range stripes {
+		for w, b := range stripe[:words] {
+			data[w*n+j] = b
+		}
+	}
+}
+
+//out = sum_j row[j] * in[j], over the first len(out) bytes of every in[j].
+//a unit row is copied, so the data shards of ModeSystematic never go through GF math.
+func mul_row(row []byte, in [][]byte, out []byte) {
+	if j := unit_row(row); j >= 0 {
+		copy(out, in[j][:len(out)])
+		return
+	}
+	for i := range out {
+		out[i] = 0
+	}
+	for j, c := range row {
+		if c != 0 {
+			mul_add(&mul_table[c], in[j][:len(out)], out)
+		}
+	}
+}
+
+//return j if row is the unit row e_j, otherwise -1
+func unit_row(row []byte) int {
+	unit := -1
+	for j, c := range row {
+		switch {
+			case c == 0:
+			case c == 1 && unit < 0:
+				unit = j
+			default:
+				return -1
+		}
+	}
+	return unit
+}
+
+//out ^= c * src, where table is mul_table[c]. 8 bytes are xored at a time.
+func mul_add(table *[256]byte, src, out []byte) {
+	for len(src) >= 8 {
+		s := src[:8]
+		v := uint64(table[s[0]]) | uint64(table[s[1]])<<8 | uint64(table[s[2]])<<16 | uint64(table[s[3]])<<24 |
+			uint64(table[s[4]])<<32 | uint64(table[s[5]])<<40 | uint64(table[s[6]])<<48 | uint64(table[s[7]])<<56
+		binary.LittleEndian.PutUint64(out, binary.LittleEndian.Uint64(out)^v)
+		src, out = src[8:], out[8:]
+	}
+	for i, b := range src {
+		out[i] ^= table[b]
+	}
+}
+
+//out[r] = mat[r] * in for every row of mat, the rows are spread over the pool
+func mul_matrix(p *workerPool, mat [][]byte, in, out [][]byte, words int) {
+	p.run(len(mat), func(r int) {
+		mul_row(mat[r], in, out[r][:words])
+	})
+}
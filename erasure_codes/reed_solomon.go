@@ -1,13 +1,33 @@
-package main
-import (
-	_"fmt"
-)
+package erasure_codes
 
 //------------------------------------
 
-//create cauchy matrix of dimensions (n+k)xn
-//every n rows suffice to reconstruct the data
-func create_cauchy(k, n byte) [][]byte{
+//Mode is the kind of coding matrix
+type Mode byte
+
+const (
+	//every shard is coded with a row of a cauchy matrix
+	ModeCauchy Mode = iota
+	//an identity block on top of cauchy rows: the first n shards are the data itself,
+	//the k others are parity
+	ModeSystematic
+)
+
+func (mode Mode) String() string {
+	switch mode {
+		case ModeCauchy:
+			return "cauchy"
+		case ModeSystematic:
+			return "systematic"
+	}
+	return "unknown"
+}
+
+//create the coding matrix of dimensions (n+k)xn, every n rows suffice to reconstruct the data.
+//In ModeCauchy it is a cauchy matrix. In ModeSystematic it is the nxn identity on top of the
+//first k rows of the cauchy matrix: every square submatrix of a cauchy matrix is invertible,
+//so the missing data can be solved from any parity rows, see create_systematic_decoder.
+func create_cauchy(k, n byte, mode Mode) [][]byte{
 	mat := make([][]byte, n+k)
 	for i := range mat {
 		mat[i] = make([]byte, n)
@@ -19,19 +39,35 @@ func create_cauchy(k, n byte) [][]byte{
 			mat[i][j-n-k] = div(1, add(i, j))
 		}
 	}
+
+	if mode == ModeSystematic {
+		copy(mat[n:], mat[:k]) //cauchy rows move below the identity
+		for i=0; i<n; i++ {
+			mat[i] = make([]byte, n)
+			mat[i][i] = 1
+		}
+	}
 	return mat
 }
 
 //from the cauchy matrix mat, select only rows from row_indexes
 func create_cauchy_submatrix(mat [][]byte, row_indexes []int) [][]byte {
 	n := len(mat[0])
-	submat := make([][]byte, n) //create matrix for cauchy
-	for i := range submat{
-		submat[i] = make([]byte, n)
+	col_indexes := make([]int, n)
+	for i := range col_indexes {
+		col_indexes[i] = i
 	}
+	return create_submatrix(mat, row_indexes, col_indexes)
+}
 
-	for i := range submat { //populate it with rows from whole cauchy matrix
-		submat[i] = mat[row_indexes[i]][:]
+//from mat, select the rows from row_indexes and the columns from col_indexes
+func create_submatrix(mat [][]byte, row_indexes, col_indexes []int) [][]byte {
+	submat := make([][]byte, len(row_indexes))
+	for i := range submat { //populate it with copies, the LU decomposition overwrites them
+		submat[i] = make([]byte, len(col_indexes))
+		for j, col := range col_indexes {
+			submat[i][j] = mat[row_indexes[i]][col]
+		}
 	}
 
 	return submat
@@ -116,6 +152,93 @@ func create_inverse(mat [][]byte, row_indexes []int) [][]byte {
 	return invert_LU(cauchy)
 }
 
+//create a function decoding the n-words coded with the rows of mat from row_indexes (sorted asc)
+func create_decoder(mat [][]byte, mode Mode, row_indexes []int) func(enc []byte) []byte {
+	if mode == ModeSystematic {
+		return create_systematic_decoder(mat, row_indexes)
+	}
+	inv := create_inverse(mat, row_indexes)
+	return func(enc []byte) []byte {
+		return decode_word(inv, enc)
+	}
+}
+
+//create the matrix decoding the n-words coded with the rows of mat from row_indexes (sorted asc).
+//the decoder of create_decoder is linear, so its matrix has the decoded unit vectors as columns.
+func create_decode_matrix(mat [][]byte, mode Mode, row_indexes []int) [][]byte {
+	n := len(mat[0])
+	decode_word := create_decoder(mat, mode, row_indexes)
+	dec := make([][]byte, n)
+	for i := range dec {
+		dec[i] = make([]byte, n)
+	}
+	unit := make([]byte, n)
+	for col := range unit {
+		unit[col] = 1
+		for row, b := range decode_word(unit) {
+			dec[row][col] = b
+		}
+		unit[col] = 0
+	}
+	return dec
+}
+
+//the data shards among row_indexes hold their bytes of the n-word as they are.
+//the missing bytes U are solved from the parity rows P: C[P][U] * word[U] = enc[P] - C[P][D] * word[D],
+//where D are the present data bytes. C[P][U] is a square submatrix of a cauchy matrix,
+//so its LU decomposition needs no pivoting. Without missing data shards there's no GF math at all.
+func create_systematic_decoder(mat [][]byte, row_indexes []int) func(enc []byte) []byte {
+	n := len(mat[0])
+	present := make([]bool, n)
+	data_rows, parity_rows := make([]int, 0, n), make([]int, 0, n)
+	for _, row := range row_indexes {
+		if row < n {
+			present[row] = true
+			data_rows = append(data_rows, row)
+		} else {
+			parity_rows = append(parity_rows, row)
+		}
+	}
+	missing := make([]int, 0, len(parity_rows))
+	for col := range present {
+		if !present[col] {
+			missing = append(missing, col)
+		}
+	}
+
+	if len(missing) == 0 {
+		return func(enc []byte) []byte {
+			word := make([]byte, n)
+			copy(word, enc)
+			return word
+		}
+	}
+
+	known := create_submatrix(mat, parity_rows, data_rows)
+	unknown := create_submatrix(mat, parity_rows, missing)
+	get_LU(unknown)
+	inv := invert_LU(unknown)
+
+	return func(enc []byte) []byte {
+		//row_indexes are sorted, so the data rows come first in enc
+		word := make([]byte, n)
+		for i, row := range data_rows {
+			word[row] = enc[i]
+		}
+		rhs := make([]byte, len(parity_rows))
+		for i := range parity_rows {
+			rhs[i] = enc[len(data_rows)+i]
+			for j, row := range data_rows {
+				rhs[i] = sub(rhs[i], mul(known[i][j], word[row]))
+			}
+		}
+		for i, b := range decode_word(inv, rhs) {
+			word[missing[i]] = b
+		}
+		return word
+	}
+}
+
 func decode_word(inv [][]byte, enc []byte) []byte{
 	dim := len(inv[0])
 
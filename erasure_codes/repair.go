@@ -0,0 +1,115 @@
+package erasure_codes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+//------------------------------------
+
+//Repair rewrites the shards of the rows in missing from any n of the other shards,
+//out[i] gets the shard of row missing[i]. The shards are read like Decode reads them, but
+//no data is written: every block is decoded with the inverse of the rows at hand and coded
+//again with the missing rows only, so the surviving shards stay as they are.
+//The decoded n-words are checked against the hash in the headers, if they don't match the
+//written shards are wrong and ErrCorrupt is returned.
+func (m *Manager) Repair(shards []io.Reader, missing []int, out []io.Writer) (Report, error) {
+	report := newReport()
+	if len(missing) != len(out) {
+		return report, errors.Wrapf(ErrInvalidParams, "%d writers for %d missing shards", len(out), len(missing))
+	}
+	for i, row := range missing {
+		if row < 0 || row >= m.Shards() {
+			return report, errors.Wrapf(ErrInvalidParams, "row %d of %d shards", row, m.Shards())
+		}
+		for _, other := range missing[:i] {
+			if row == other {
+				return report, errors.Wrapf(ErrInvalidParams, "row %d is missing twice", row)
+			}
+		}
+	}
+
+	readers, err := m.selectShards(shards, report)
+	if err != nil {
+		return report, err
+	}
+	header := readers[0].header
+	for i, w := range out {
+		header.Row = missing[i]
+		if _, err := w.Write(header.marshal()); err != nil {
+			return report, errors.Wrapf(err, "writing shard %d", missing[i])
+		}
+	}
+
+	p := newWorkerPool(m.workers)
+	defer p.close()
+	mat := m.mats[header.Mode]
+	missingRows := create_submatrix(mat, missing, seq(m.n))
+	decoders := newDecodeMatrices(mat, header.Mode)
+	h := sha256.New()
+	blocks := make([][]byte, m.n)
+	rows := make([]int, m.n)
+	indexes := make([]int, m.n)
+	stripes := make_stripes(m.n, header.BlockSize)
+	decoded := make([]byte, header.BlockSize*m.n)
+	coded := make_stripes(len(missing), header.BlockSize)
+	remaining := header.Length
+
+	for b := uint64(0); b < header.Blocks(); b++ {
+		if chosen := readBlocks(readers, b, m.n, report, blocks, rows, indexes); chosen < m.n {
+			return report, errors.Wrapf(ErrTooFewShards, "block %d is intact in %d shards, need %d", b, chosen, m.n)
+		}
+
+		words := header.blockLen(b)
+		mul_matrix(p, decoders.get(rows), blocks, stripes, words)
+		mul_matrix(p, missingRows, stripes, coded, words)
+		decoded = decoded[:words*m.n]
+		interleave(stripes, decoded, words)
+
+		for i, w := range out {
+			if _, err := w.Write(appendCRC(coded[i][:words])); err != nil {
+				return report, errors.Wrapf(err, "writing shard %d", missing[i])
+			}
+		}
+		if uint64(len(decoded)) > remaining { //the padding of the last n-word isn't hashed
+			decoded = decoded[:remaining]
+		}
+		h.Write(decoded)
+		remaining -= uint64(len(decoded))
+	}
+
+	if !bytes.Equal(h.Sum(nil), header.Hash[:]) {
+		return report, errors.Wrap(ErrCorrupt, "decoded data does not match the hash in the shards")
+	}
+	return report, nil
+}
+
+//RepairBytes rewrites the shards of the rows in missing from shards held in memory,
+//missing shards are nil. It returns the repaired shards in the order of missing.
+func (m *Manager) RepairBytes(shards [][]byte, missing []int) ([][]byte, error) {
+	readers := make([]io.Reader, 0, len(shards))
+	for _, shard := range shards {
+		if shard != nil {
+			readers = append(readers, bytes.NewReader(shard))
+		}
+	}
+	bufs := make([]*bytes.Buffer, len(missing))
+	writers := make([]io.Writer, len(missing))
+	for i := range bufs {
+		bufs[i] = new(bytes.Buffer)
+		writers[i] = bufs[i]
+	}
+
+	if _, err := m.Repair(readers, missing, writers); err != nil {
+		return nil, err
+	}
+
+	repaired := make([][]byte, len(bufs))
+	for i, buf := range bufs {
+		repaired[i] = buf.Bytes()
+	}
+	return repaired, nil
+}
@@ -3,7 +3,8 @@ module distry
 go 1.16
 
 require (
-	github.com/gogo/protobuf v1.3.2 // indirect
+	filippo.io/edwards25519 v1.0.0
+	github.com/gogo/protobuf v1.3.2
 	github.com/golang/protobuf v1.5.2
 	github.com/hashicorp/errwrap v1.1.0 // indirect
 	github.com/ipfs/go-ipns v0.1.0 // indirect
@@ -30,4 +31,5 @@ require (
 	golang.org/x/sys v0.0.0-20210525143221-35b2ab0089ea // indirect
 	google.golang.org/genproto v0.0.0-20210524171403-669157292da3 // indirect
 	google.golang.org/grpc v1.38.0
+	google.golang.org/protobuf v1.26.0
 )
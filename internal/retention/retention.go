@@ -0,0 +1,133 @@
+//Package retention holds what the protocol managers share to bound the state they keep
+//of their instances (rounds, sessions, epochs): when finished and stalled instances are evicted,
+//how late messages of evicted instances are recognized, and the expvar gauges of the counts.
+package retention
+
+import(
+	"expvar"
+	"sort"
+	"time"
+)
+
+//Expired returns how many of count finished instances, oldest first, are evicted at now:
+//those which finished more than maxAge ago and the oldest beyond maxCount.
+//finishedAt(i) is when the i-th oldest instance finished.
+func Expired(now time.Time, maxAge time.Duration, maxCount, count int, finishedAt func(i int) time.Time) int{
+	expired := 0
+	for expired < count && (count-expired > maxCount || now.Sub(finishedAt(expired)) > maxAge){
+		expired++
+	}
+	return expired
+}
+
+//Stalled tells whether an unfinished instance which last saw a message at lastActivity is evicted
+func Stalled(now, lastActivity time.Time, timeout time.Duration) bool{
+	return now.Sub(lastActivity) > timeout
+}
+
+//------------------------------------
+
+//LowWater remembers which instances were evicted, so their late messages don't start them anew.
+//an instance is identified by a key and a counter, the counters of a key grow over time
+//(the rounds of one initiator in one stream, the sessions of dkg).
+//
+//the last window evicted counters of a key are kept one by one, so a lower counter which wasn't
+//seen yet is not taken for an evicted one. The mark only advances over the evicted counters:
+//once more than window are kept, the lowest is dropped and becomes the low-water mark,
+//every counter up to it is late. A few odd counters far above the rest sit at the top
+//and never become the mark, it takes window+1 of them.
+//
+//only evict instances into it which no one but their initiator can make this node start
+//(started by the initiator's own message, or finished): messages of unknown instances start them,
+//and if those raised the mark, anyone could silence an initiator with messages of huge counters.
+type LowWater struct{
+	window int
+	//map [ KEY -> evicted counters ]
+	keys map[string]*evicted
+}
+
+type evicted struct{
+	mark uint64
+	marked bool
+	//above mark, sorted asc
+	counters []uint64
+}
+
+func NewLowWater(window int) *LowWater{
+	if window < 1{
+		window = 1
+	}
+	return &LowWater{window: window, keys: make(map[string]*evicted)}
+}
+
+//Evict records the eviction of the instance counter of key
+func (lw *LowWater) Evict(key string, counter uint64){
+	e, exists := lw.keys[key]
+	if !exists{
+		e = &evicted{}
+		lw.keys[key] = e
+	}
+	if e.marked && counter <= e.mark{
+		return
+	}
+
+	ix := sort.Search(len(e.counters), func(i int) bool{ return e.counters[i] >= counter })
+	if ix < len(e.counters) && e.counters[ix] == counter{
+		return
+	}
+	e.counters = append(e.counters, 0)
+	copy(e.counters[ix+1:], e.counters[ix:])
+	e.counters[ix] = counter
+
+	if len(e.counters) > lw.window{
+		e.mark, e.marked = e.counters[0], true
+		e.counters = e.counters[1:]
+	}
+}
+
+//IsLate tells whether the instance counter of key was evicted
+func (lw *LowWater) IsLate(key string, counter uint64) bool{
+	e, exists := lw.keys[key]
+	if !exists{
+		return false
+	}
+	if e.marked && counter <= e.mark{
+		return true
+	}
+	ix := sort.Search(len(e.counters), func(i int) bool{ return e.counters[i] >= counter })
+	return ix < len(e.counters) && e.counters[ix] == counter
+}
+
+//Prune forgets the evicted instances of the keys keep returns false for, the keys
+//of initiators which left: the marks of keys which never come back would pile up
+func (lw *LowWater) Prune(keep func(key string) bool){
+	for key := range lw.keys{
+		if !keep(key){
+			delete(lw.keys, key)
+		}
+	}
+}
+
+//Len returns the number of keys with evicted instances
+func (lw *LowWater) Len() int{
+	return len(lw.keys)
+}
+
+//------------------------------------
+
+//Metrics are the counts of a manager, served by expvar under /debug/vars
+type Metrics struct{
+	*expvar.Map
+}
+
+//NewMetrics publishes the metrics of the manager name, it panics if name is published twice
+func NewMetrics(name string) Metrics{
+	return Metrics{expvar.NewMap(name)}
+}
+
+//SetGauge sets the current value of a count
+func (m Metrics) SetGauge(name string, value int){
+	gauge := new(expvar.Int)
+	gauge.Set(int64(value))
+	m.Set(name, gauge)
+}
@@ -0,0 +1,65 @@
+package retention
+
+import(
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLowWater(t *testing.T){
+	lw := NewLowWater(3)
+	if lw.IsLate("a", 0){
+		t.Fatal("nothing was evicted yet")
+	}
+
+	//evicted out of order, the counters not seen yet are not late
+	lw.Evict("a", 5)
+	lw.Evict("a", 7)
+	for counter, late := range map[uint64]bool{4: false, 5: true, 6: false, 7: true, 8: false}{
+		if lw.IsLate("a", counter) != late{
+			t.Fatalf("counter %d: expected late %v", counter, late)
+		}
+	}
+	if lw.IsLate("b", 5){
+		t.Fatal("the counters of another key are not late")
+	}
+
+	//once more than the window were evicted, the lowest becomes the mark
+	lw.Evict("a", 6)
+	lw.Evict("a", 9)
+	for counter, late := range map[uint64]bool{0: true, 4: true, 5: true, 8: false, 9: true}{
+		if lw.IsLate("a", counter) != late{
+			t.Fatalf("counter %d: expected late %v", counter, late)
+		}
+	}
+
+	//odd counters far above the rest don't become the mark
+	for i := uint64(0); i < 3; i++{
+		lw.Evict("a", math.MaxUint64 - i)
+	}
+	if lw.IsLate("a", 10) || !lw.IsLate("a", math.MaxUint64){
+		t.Fatal("odd counters are not kept above the mark")
+	}
+	for counter := uint64(8); counter < 20; counter++{
+		lw.Evict("a", counter)
+	}
+	if !lw.IsLate("a", 18) || lw.IsLate("a", 20) || lw.IsLate("a", 1000){
+		t.Fatal("the mark did not follow the evicted counters")
+	}
+}
+
+func TestExpired(t *testing.T){
+	now := time.Now()
+	finished := []time.Time{now.Add(-3*time.Hour), now.Add(-2*time.Hour), now.Add(-time.Minute), now}
+	finishedAt := func(i int) time.Time{ return finished[i] }
+
+	if expired := Expired(now, time.Hour, 10, len(finished), finishedAt); expired != 2{
+		t.Fatalf("expected the 2 oldest expired by age, got %d", expired)
+	}
+	if expired := Expired(now, 24*time.Hour, 1, len(finished), finishedAt); expired != 3{
+		t.Fatalf("expected all but the youngest expired by count, got %d", expired)
+	}
+	if expired := Expired(now, 24*time.Hour, 10, 0, finishedAt); expired != 0{
+		t.Fatalf("expected none expired, got %d", expired)
+	}
+}
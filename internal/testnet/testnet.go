@@ -0,0 +1,224 @@
+//Package testnet connects the managers of several nodes in one process for their tests.
+//a Node stands in for omni.Manager of a single node, and for its rbc0.Manager with
+//broadcasts which skip the protocol and are delivered to every node right away.
+package testnet
+
+import(
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"distry/membership"
+	"distry/messages"
+	genmsg "distry/proto_gen/messages"
+	"distry/rbc0"
+)
+
+//Network passes the messages between its Nodes. every published message reaches every other
+//node, every direct message its recipient and every broadcast every node.
+type Network struct{
+	//Drop decides which messages never arrive, nil drops none.
+	//msg is the message as the recipient gets it: with the sender set, or an Rbc0Delivery
+	Drop	func(from, to peer.ID, msg messages.Message) bool
+
+	nodes		[]*Node
+	nodesLock	sync.RWMutex
+}
+
+func (n *Network) nodeList() []*Node{
+	n.nodesLock.RLock()
+	defer n.nodesLock.RUnlock()
+	return n.nodes
+}
+
+//AddNode connects a node with id to the network
+func (n *Network) AddNode(id peer.ID) *Node{
+	node := &Node{id: id, network: n, inboxC: make(chan struct{}, 1), typePubs: make(map[genmsg.Message_Type]messages.Publisher)}
+	n.nodesLock.Lock()
+	n.nodes = append(n.nodes, node)
+	n.nodesLock.Unlock()
+	go node.receiver()
+	return node
+}
+
+func (n *Network) send(from *Node, to *Node, msg messages.Message, rbc bool){
+	if n.Drop != nil && n.Drop(from.id, to.id, msg){
+		return
+	}
+	to.enqueue(envelope{msg: msg, rbc: rbc})
+}
+
+//------------------------------------
+
+type envelope struct{
+	msg	messages.Message
+	rbc	bool //a delivery for the SubscribeToMessages subscribers, not for a handler
+}
+
+//Node is a single node of a Network
+type Node struct{
+	id			peer.ID
+	network	*Network
+
+	//messages wait here until the receiver picks them up, so publishing never blocks
+	//(like publishing to the pubsub topic)
+	inbox			[]envelope
+	inboxLock	sync.Mutex
+	inboxC		chan struct{}
+
+	//map [ MESSAGE_TYPE -> publisher of the dispatch of the type's handler ]
+	typePubs	map[genmsg.Message_Type]messages.Publisher
+	rbcPubs	[]messages.Publisher
+	pubsLock	sync.Mutex
+}
+
+func (o *Node) ID() peer.ID{
+	return o.id
+}
+
+//OmniPublisher sends msg to every other node, as sent by this node
+func (o *Node) OmniPublisher(msg messages.Message) error{
+	msg = o.sent(msg)
+	for _, node := range o.network.nodeList(){
+		if node != o{ //own messages don't come back from the omni network
+			o.network.send(o, node, msg, false)
+		}
+	}
+	return nil
+}
+
+//SendOrPublish sends msg to peerID only, or publishes it if peerID is not in the network
+func (o *Node) SendOrPublish(peerID peer.ID, msg messages.Message){
+	for _, node := range o.network.nodeList(){
+		if node.id == peerID{
+			o.network.send(o, node, messages.Direct{Message: o.sent(msg)}, false)
+			return
+		}
+	}
+	o.OmniPublisher(msg)
+}
+
+//Register hands the received messages of the codec's type to its Handler, like omni does.
+//the messages are never marshalled, so only the Handler is used
+func (o *Node) Register(codec messages.Codec){
+	pub, sub := messages.NewBufferedSubscription(messages.DefaultSubscriptionConfig())
+	o.pubsLock.Lock()
+	defer o.pubsLock.Unlock()
+	if _, exists := o.typePubs[codec.Type]; exists{
+		panic("testnet: codec of " + codec.Type.String() + " registered twice")
+	}
+	o.typePubs[codec.Type] = pub
+
+	go func(){
+		for{
+			msg, err := sub.Next()
+			if err != nil{
+				return
+			}
+			codec.Handler(msg)
+		}
+	}()
+}
+
+//BroadcastInstance delivers payload to every node as accepted in the rbc0 round
+//this node would broadcast for instance counter of stream
+func (o *Node) BroadcastInstance(_ context.Context, stream string, counter uint64, payload string) (messages.Rbc0Delivery, error){
+	delivery := messages.Rbc0Delivery{
+		SenderID:	o.id.String(),
+		ProtocolID:	rbc0.InstanceID(o.id.String(), stream, counter),
+		Payload:		payload,
+		AcceptedAt:	time.Now(),
+	}
+	for _, node := range o.network.nodeList(){
+		o.network.send(o, node, delivery, true)
+	}
+	return delivery, nil
+}
+
+//SubscribeToMessages returns the deliveries of BroadcastInstance
+func (o *Node) SubscribeToMessages() messages.Subscriber{
+	return o.subscribe(&o.rbcPubs)
+}
+
+//Receive hands msg to the handler of its type as if it came from the network,
+//msg carries its sender
+func (o *Node) Receive(msg messages.Message){
+	o.enqueue(envelope{msg: msg})
+}
+
+//Deliver hands delivery to the SubscribeToMessages subscribers as if rbc0 delivered it,
+//tests use it to deliver late what their Drop held back
+func (o *Node) Deliver(delivery messages.Rbc0Delivery){
+	o.enqueue(envelope{msg: delivery, rbc: true})
+}
+
+//the value of the message pointer msg with this node as its sender, as omni would send it
+func (o *Node) sent(msg messages.Message) messages.Message{
+	out := reflect.New(reflect.TypeOf(msg).Elem())
+	out.Elem().Set(reflect.ValueOf(msg).Elem())
+	out.Interface().(messages.Sealable).SetSender(o.id.String())
+	return out.Elem().Interface().(messages.Message)
+}
+
+func (o *Node) subscribe(pubs *[]messages.Publisher) messages.Subscriber{
+	pub, sub := messages.NewSubscription()
+	o.pubsLock.Lock()
+	defer o.pubsLock.Unlock()
+	*pubs = append(*pubs, pub)
+	return sub
+}
+
+func (o *Node) enqueue(env envelope){
+	o.inboxLock.Lock()
+	o.inbox = append(o.inbox, env)
+	o.inboxLock.Unlock()
+
+	select{
+		case o.inboxC <- struct{}{}:
+		default:
+	}
+}
+
+//hand queued messages to the handlers and subscribers
+func (o *Node) receiver(){
+	for range o.inboxC{
+		for{
+			o.inboxLock.Lock()
+			if len(o.inbox) == 0{
+				o.inboxLock.Unlock()
+				break
+			}
+			env := o.inbox[0]
+			o.inbox = o.inbox[1:]
+			o.inboxLock.Unlock()
+
+			o.pubsLock.Lock()
+			pubs := o.rbcPubs
+			if !env.rbc{
+				//messages of types no manager of the node registered are dropped, like omni does
+				pubs = nil
+				if pub, exists := o.typePubs[messages.Unwrap(env.msg).(messages.SignedMessage).MessageType()]; exists{
+					pubs = []messages.Publisher{pub}
+				}
+			}
+			o.pubsLock.Unlock()
+			for _, pub := range pubs{
+				_ = pub.Publish(env.msg)
+			}
+		}
+	}
+}
+
+//------------------------------------
+
+//Membership stands in for membership.Manager, its epoch never changes
+type Membership struct{
+	Epoch membership.Epoch
+}
+
+func (f Membership) Current() membership.Epoch{
+	return f.Epoch
+}
@@ -0,0 +1,124 @@
+package membership
+
+import(
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"go.uber.org/zap"
+
+	"distry/omni"
+)
+
+//Epoch is a numbered snapshot of the nodes taking part in the omni network.
+//every time a peer joins or leaves the omni topic, a new epoch begins.
+type Epoch struct{
+	Number	uint64
+	Peers		[]peer.ID //sorted, including this node
+	N			int //number of nodes in the network (matching bracha's naming scheme)
+}
+
+//Manager follows the peers of the omni topic and keeps the current epoch
+type Manager struct{
+	logger	*zap.Logger
+	nodeID	peer.ID
+
+	//set of peers currently subscribed to the omni topic, excluding this node
+	peers		map[peer.ID]struct{}
+	epoch		Epoch
+	epochLock	sync.RWMutex
+}
+
+func NewManager(logger *zap.Logger, omniManager *omni.Manager) (*Manager, error){
+	m := newManager(logger, omniManager.NodeID)
+
+	//the handler first reports a join for every peer already in the topic
+	events, err := omniManager.PeerEvents()
+	if err != nil{
+		m.logger.Error("failed following omni topic peers", zap.Error(err))
+		return nil, err
+	}
+
+	go m.peerEventReceiver(events)
+	return m, nil
+}
+
+//create a Manager of node nodeID in the epoch 0 of only itself
+func newManager(logger *zap.Logger, nodeID peer.ID) *Manager{
+	if logger == nil{
+		logger = zap.NewNop()
+	}
+
+	m := &Manager{
+		logger:	logger,
+		nodeID:	nodeID,
+		peers:	make(map[peer.ID]struct{}),
+	}
+	m.epoch = m.newEpoch(0)
+	return m
+}
+
+//Current returns the epoch the network is in
+func (m *Manager) Current() Epoch{
+	m.epochLock.RLock()
+	defer m.epochLock.RUnlock()
+
+	return m.epoch
+}
+
+//receive joins and leaves of the omni topic
+func (m *Manager) peerEventReceiver(events *pubsub.TopicEventHandler){
+	for{
+		event, err := events.NextPeerEvent(context.Background())
+		if err != nil{
+			m.logger.Error("failed receiving omni topic peer event", zap.Error(err))
+			return
+		}
+		m.handlePeerEvent(event)
+	}
+}
+
+//start a new epoch if the peers change
+func (m *Manager) handlePeerEvent(event pubsub.PeerEvent){
+	m.epochLock.Lock()
+	defer m.epochLock.Unlock()
+
+	_, known := m.peers[event.Peer]
+	switch event.Type{
+		case pubsub.PeerJoin:
+			if known || event.Peer == m.nodeID{
+				return
+			}
+			m.peers[event.Peer] = struct{}{}
+		case pubsub.PeerLeave:
+			if !known{
+				return
+			}
+			delete(m.peers, event.Peer)
+	}
+	m.epoch = m.newEpoch(m.epoch.Number + 1)
+
+	m.logger.Info("new membership epoch",
+		zap.Uint64("epoch", m.epoch.Number),
+		zap.Int("n", m.epoch.N),
+	)
+}
+
+//create epoch with the given number from the current set of peers.
+//caller must hold epochLock
+func (m *Manager) newEpoch(number uint64) Epoch{
+	peers := make([]peer.ID, 0, len(m.peers)+1)
+	peers = append(peers, m.nodeID)
+	for p := range m.peers{
+		peers = append(peers, p)
+	}
+	sort.Slice(peers, func(i, j int) bool{ return peers[i] < peers[j] })
+
+	return Epoch{
+		Number:	number,
+		Peers:	peers,
+		N:			len(peers),
+	}
+}
@@ -0,0 +1,41 @@
+package membership
+
+import(
+	"reflect"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+func TestEpochs(t *testing.T){
+	m := newManager(nil, "node1")
+	expect := func(number uint64, peers ...peer.ID){
+		t.Helper()
+		epoch := m.Current()
+		if epoch.Number != number || epoch.N != len(peers) || !reflect.DeepEqual(epoch.Peers, peers){
+			t.Fatalf("got epoch %d of %v (n=%d), want epoch %d of %v", epoch.Number, epoch.Peers, epoch.N, number, peers)
+		}
+	}
+	join := func(p peer.ID){ m.handlePeerEvent(pubsub.PeerEvent{Type: pubsub.PeerJoin, Peer: p}) }
+	leave := func(p peer.ID){ m.handlePeerEvent(pubsub.PeerEvent{Type: pubsub.PeerLeave, Peer: p}) }
+
+	expect(0, "node1")
+
+	//every join begins a new epoch, the peers are sorted
+	join("node2")
+	expect(1, "node1", "node2")
+	join("node0")
+	expect(2, "node0", "node1", "node2")
+
+	//joins of known peers or of this node and leaves of unknown peers change nothing
+	join("node2")
+	join("node1")
+	leave("node3")
+	expect(2, "node0", "node1", "node2")
+
+	leave("node2")
+	expect(3, "node0", "node1")
+	join("node2")
+	expect(4, "node0", "node1", "node2")
+}
@@ -0,0 +1,108 @@
+package messages
+
+import(
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	genmsg "distry/proto_gen/messages"
+)
+
+
+type MsgAba struct{
+	Type uint32;
+	SenderID, SessionID string;
+	Round uint32;
+	Value bool;
+	Signature []byte;
+}
+func (m MsgAba) MarshalToProtobuf() *genmsg.Message{
+	return &genmsg.Message{
+		Type: genmsg.Message_ABA,
+		Aba: &genmsg.Aba{
+			SenderId:		m.SenderID,
+			SessionId:		m.SessionID,
+			Type:				m.Type,
+			Round:			m.Round,
+			Value:			m.Value,
+			Signature:		m.Signature,
+		},
+	}
+}
+
+func (m MsgAba) Sender() string{
+	return m.SenderID
+}
+
+func (m MsgAba) MessageType() genmsg.Message_Type{
+	return genmsg.Message_ABA
+}
+
+func (m MsgAba) GetSignature() []byte{
+	return m.Signature
+}
+
+//the bytes the sender signs: every field of the message except the signature itself
+func (m MsgAba) SigningBytes() []byte{
+	return canonicalEncoding(genmsg.Message_ABA,
+		m.SenderID,
+		m.SessionID,
+		strconv.FormatUint(uint64(m.Type), 10),
+		strconv.FormatUint(uint64(m.Round), 10),
+		strconv.FormatBool(m.Value),
+	)
+}
+
+//SetSender and SetSignature let omni complete an outgoing message
+func (m *MsgAba) SetSender(senderID string){
+	m.SenderID = senderID
+}
+
+func (m *MsgAba) SetSignature(signature []byte){
+	m.Signature = signature
+}
+
+//MarshalAba and UnmarshalAba make up the codec of aba messages, see Codec
+func MarshalAba(msg SignedMessage) (*genmsg.Message, error){
+	switch m := msg.(type){
+		case MsgAba:
+			return m.MarshalToProtobuf(), nil
+		case *MsgAba:
+			return m.MarshalToProtobuf(), nil
+	}
+	return nil, errors.Errorf("%T is no aba message", msg)
+}
+
+func UnmarshalAba(m *genmsg.Message) (SignedMessage, error){
+	if m.Aba == nil{
+		return nil, errMissingBody
+	}
+	return MsgAba{
+		SenderID:		m.Aba.SenderId,
+		SessionID:		m.Aba.SessionId,
+		Type:				m.Aba.Type,
+		Round:			m.Aba.Round,
+		Value:			m.Aba.Value,
+		Signature:		m.Aba.Signature,
+	}, nil
+}
+
+
+//AbaDecision is handed by aba to other parts of the node once a session decides.
+//it never leaves the node.
+type AbaDecision struct{
+	SessionID	string
+	Value			bool
+	Round			uint32 //round in which this node decided
+}
+func (m AbaDecision) MarshalToProtobuf() *genmsg.Message{
+	return &genmsg.Message{
+		Type: genmsg.Message_ABA,
+		Aba: &genmsg.Aba{
+			SessionId:		m.SessionID,
+			Type:				4,
+			Round:			m.Round,
+			Value:			m.Value,
+		},
+	}
+}
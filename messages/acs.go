@@ -0,0 +1,31 @@
+package messages
+
+import(
+	genmsg "distry/proto_gen/messages"
+)
+
+
+type AcsProposal struct{
+	ProposerID	string
+	Payload		string
+}
+
+//AcsOutput is handed by acs to other parts of the node once an epoch is over.
+//it never leaves the node.
+type AcsOutput struct{
+	Epoch			uint64
+	Proposals	[]AcsProposal //sorted by ProposerID
+}
+func (m AcsOutput) MarshalToProtobuf() *genmsg.Message{
+	proposals := make([]*genmsg.AcsProposal, len(m.Proposals))
+	for i, proposal := range m.Proposals{
+		proposals[i] = &genmsg.AcsProposal{ProposerId: proposal.ProposerID, Payload: proposal.Payload}
+	}
+	return &genmsg.Message{
+		Type: genmsg.Message_ACS,
+		Acs: &genmsg.Acs{
+			Epoch:		m.Epoch,
+			Proposals:	proposals,
+		},
+	}
+}
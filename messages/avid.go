@@ -0,0 +1,127 @@
+package messages
+
+import(
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	genmsg "distry/proto_gen/messages"
+)
+
+
+type MsgAvid struct{
+	Type uint32;
+	SenderID, ProtocolID, RecipientID string;
+	Root []byte;
+	Shards, Index uint32;
+	Fragment []byte;
+	Proof [][]byte;
+	Signature []byte;
+}
+func (m MsgAvid) MarshalToProtobuf() *genmsg.Message{
+	return &genmsg.Message{
+		Type: genmsg.Message_AVID,
+		Avid: &genmsg.Avid{
+			SenderId:		m.SenderID,
+			ProtocolId:		m.ProtocolID,
+			Type:				m.Type,
+			RecipientId:	m.RecipientID,
+			Root:				m.Root,
+			Shards:			m.Shards,
+			Index:			m.Index,
+			Fragment:		m.Fragment,
+			Proof:			m.Proof,
+			Signature:		m.Signature,
+		},
+	}
+}
+
+func (m MsgAvid) Sender() string{
+	return m.SenderID
+}
+
+func (m MsgAvid) MessageType() genmsg.Message_Type{
+	return genmsg.Message_AVID
+}
+
+func (m MsgAvid) GetSignature() []byte{
+	return m.Signature
+}
+
+//the bytes the sender signs: every field of the message except the signature itself
+func (m MsgAvid) SigningBytes() []byte{
+	fields := []string{
+		m.SenderID,
+		m.ProtocolID,
+		strconv.FormatUint(uint64(m.Type), 10),
+		m.RecipientID,
+		string(m.Root),
+		strconv.FormatUint(uint64(m.Shards), 10),
+		strconv.FormatUint(uint64(m.Index), 10),
+		string(m.Fragment),
+	}
+	for _, hash := range m.Proof{
+		fields = append(fields, string(hash))
+	}
+	return canonicalEncoding(genmsg.Message_AVID, fields...)
+}
+
+//SetSender and SetSignature let omni complete an outgoing message
+func (m *MsgAvid) SetSender(senderID string){
+	m.SenderID = senderID
+}
+
+func (m *MsgAvid) SetSignature(signature []byte){
+	m.Signature = signature
+}
+
+//MarshalAvid and UnmarshalAvid make up the codec of avid messages, see Codec
+func MarshalAvid(msg SignedMessage) (*genmsg.Message, error){
+	switch m := msg.(type){
+		case MsgAvid:
+			return m.MarshalToProtobuf(), nil
+		case *MsgAvid:
+			return m.MarshalToProtobuf(), nil
+	}
+	return nil, errors.Errorf("%T is no avid message", msg)
+}
+
+func UnmarshalAvid(m *genmsg.Message) (SignedMessage, error){
+	if m.Avid == nil{
+		return nil, errMissingBody
+	}
+	return MsgAvid{
+		SenderID:		m.Avid.SenderId,
+		ProtocolID:		m.Avid.ProtocolId,
+		Type:				m.Avid.Type,
+		RecipientID:	m.Avid.RecipientId,
+		Root:				m.Avid.Root,
+		Shards:			m.Avid.Shards,
+		Index:			m.Avid.Index,
+		Fragment:		m.Avid.Fragment,
+		Proof:			m.Avid.Proof,
+		Signature:		m.Avid.Signature,
+	}, nil
+}
+
+
+//AvidDelivery is handed by avid to other parts of the node once a round is ACCEPTED.
+//it never leaves the node. It is marshalled as the '4':ACCEPTED stage of its round.
+type AvidDelivery struct{
+	SenderID		string //ID of the node which dispersed the payload
+	ProtocolID	string
+	Payload		[]byte //payload rebuilt from the fragments
+	AcceptedAt	time.Time
+}
+func (m AvidDelivery) MarshalToProtobuf() *genmsg.Message{
+	return &genmsg.Message{
+		Type: genmsg.Message_AVID,
+		Avid: &genmsg.Avid{
+			SenderId:		m.SenderID,
+			ProtocolId:		m.ProtocolID,
+			Type:				4,
+			Fragment:		m.Payload,
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package messages
+
+import(
+	"crypto/sha256"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	genmsg "distry/proto_gen/messages"
+)
+
+
+//CbcEcho is the signature of one node over the round and payload it ECHOed,
+//a quorum of them makes the certificate of a consistent broadcast round
+type CbcEcho struct{
+	SignerID string;
+	Signature []byte;
+}
+
+type MsgCbc struct{
+	Type uint32;
+	SenderID, ProtocolID, Payload string;
+	EchoSignature []byte;
+	Certificate []CbcEcho;
+	Signature []byte;
+}
+func (m MsgCbc) MarshalToProtobuf() *genmsg.Message{
+	certificate := make([]*genmsg.CbcEcho, len(m.Certificate))
+	for i, echo := range m.Certificate{
+		certificate[i] = &genmsg.CbcEcho{SignerId: echo.SignerID, Signature: echo.Signature}
+	}
+	return &genmsg.Message{
+		Type: genmsg.Message_CBC,
+		Cbc: &genmsg.Cbc{
+			SenderId:		m.SenderID,
+			ProtocolId:		m.ProtocolID,
+			Type:				m.Type,
+			Payload:			m.Payload,
+			EchoSignature:	m.EchoSignature,
+			Certificate:	certificate,
+			Signature:		m.Signature,
+		},
+	}
+}
+
+func (m MsgCbc) Sender() string{
+	return m.SenderID
+}
+
+func (m MsgCbc) MessageType() genmsg.Message_Type{
+	return genmsg.Message_CBC
+}
+
+func (m MsgCbc) GetSignature() []byte{
+	return m.Signature
+}
+
+//the bytes the sender signs: every field of the message except the signature itself
+func (m MsgCbc) SigningBytes() []byte{
+	fields := []string{
+		m.SenderID,
+		m.ProtocolID,
+		strconv.FormatUint(uint64(m.Type), 10),
+		m.Payload,
+		string(m.EchoSignature),
+	}
+	for _, echo := range m.Certificate{
+		fields = append(fields, echo.SignerID, string(echo.Signature))
+	}
+	return canonicalEncoding(genmsg.Message_CBC, fields...)
+}
+
+//SetSender and SetSignature let omni complete an outgoing message
+func (m *MsgCbc) SetSender(senderID string){
+	m.SenderID = senderID
+}
+
+func (m *MsgCbc) SetSignature(signature []byte){
+	m.Signature = signature
+}
+
+//MarshalCbc and UnmarshalCbc make up the codec of cbc messages, see Codec
+func MarshalCbc(msg SignedMessage) (*genmsg.Message, error){
+	switch m := msg.(type){
+		case MsgCbc:
+			return m.MarshalToProtobuf(), nil
+		case *MsgCbc:
+			return m.MarshalToProtobuf(), nil
+	}
+	return nil, errors.Errorf("%T is no cbc message", msg)
+}
+
+func UnmarshalCbc(m *genmsg.Message) (SignedMessage, error){
+	if m.Cbc == nil{
+		return nil, errMissingBody
+	}
+	certificate := make([]CbcEcho, len(m.Cbc.Certificate))
+	for i, echo := range m.Cbc.Certificate{
+		certificate[i] = CbcEcho{SignerID: echo.SignerId, Signature: echo.Signature}
+	}
+	return MsgCbc{
+		SenderID:		m.Cbc.SenderId,
+		ProtocolID:		m.Cbc.ProtocolId,
+		Type:				m.Cbc.Type,
+		Payload:			m.Cbc.Payload,
+		EchoSignature:	m.Cbc.EchoSignature,
+		Certificate:	certificate,
+		Signature:		m.Cbc.Signature,
+	}, nil
+}
+
+//CbcEchoBytes returns the statement a node signs when it ECHOes payload in round protocolID.
+//it doesn't depend on who signs it, so the signatures of a quorum can be checked by anyone.
+func CbcEchoBytes(protocolID, payload string) []byte{
+	digest := sha256.Sum256([]byte(payload))
+	return canonicalEncoding(genmsg.Message_CBC, "ECHO", protocolID, string(digest[:]))
+}
+
+
+//CbcDelivery is handed by cbc to other parts of the node once a round is delivered.
+//it never leaves the node. It is marshalled as the '4':DELIVERED stage of its round.
+type CbcDelivery struct{
+	SenderID		string //ID of the node which SENT the payload
+	ProtocolID	string
+	Payload		string
+	Certificate	[]CbcEcho //proves to anyone that a quorum ECHOed the payload
+	AcceptedAt	time.Time
+}
+func (m CbcDelivery) MarshalToProtobuf() *genmsg.Message{
+	return MsgCbc{
+		Type:				4,
+		SenderID:		m.SenderID,
+		ProtocolID:		m.ProtocolID,
+		Payload:			m.Payload,
+		Certificate:	m.Certificate,
+	}.MarshalToProtobuf()
+}
@@ -0,0 +1,86 @@
+package messages
+
+import(
+	"github.com/pkg/errors"
+
+	genmsg "distry/proto_gen/messages"
+)
+
+
+//MsgCoin carries a node's share of the threshold coin of the given name,
+//together with a proof that the share was computed with the node's key share.
+type MsgCoin struct{
+	SenderID, Name string;
+	Share, Challenge, Response []byte;
+	Signature []byte;
+}
+func (m MsgCoin) MarshalToProtobuf() *genmsg.Message{
+	return &genmsg.Message{
+		Type: genmsg.Message_COIN,
+		Coin: &genmsg.Coin{
+			SenderId:		m.SenderID,
+			Name:				m.Name,
+			Share:			m.Share,
+			Challenge:		m.Challenge,
+			Response:		m.Response,
+			Signature:		m.Signature,
+		},
+	}
+}
+
+func (m MsgCoin) Sender() string{
+	return m.SenderID
+}
+
+func (m MsgCoin) MessageType() genmsg.Message_Type{
+	return genmsg.Message_COIN
+}
+
+func (m MsgCoin) GetSignature() []byte{
+	return m.Signature
+}
+
+//the bytes the sender signs: every field of the message except the signature itself
+func (m MsgCoin) SigningBytes() []byte{
+	return canonicalEncoding(genmsg.Message_COIN,
+		m.SenderID,
+		m.Name,
+		string(m.Share),
+		string(m.Challenge),
+		string(m.Response),
+	)
+}
+
+//SetSender and SetSignature let omni complete an outgoing message
+func (m *MsgCoin) SetSender(senderID string){
+	m.SenderID = senderID
+}
+
+func (m *MsgCoin) SetSignature(signature []byte){
+	m.Signature = signature
+}
+
+//MarshalCoin and UnmarshalCoin make up the codec of coin messages, see Codec
+func MarshalCoin(msg SignedMessage) (*genmsg.Message, error){
+	switch m := msg.(type){
+		case MsgCoin:
+			return m.MarshalToProtobuf(), nil
+		case *MsgCoin:
+			return m.MarshalToProtobuf(), nil
+	}
+	return nil, errors.Errorf("%T is no coin message", msg)
+}
+
+func UnmarshalCoin(m *genmsg.Message) (SignedMessage, error){
+	if m.Coin == nil{
+		return nil, errMissingBody
+	}
+	return MsgCoin{
+		SenderID:		m.Coin.SenderId,
+		Name:				m.Coin.Name,
+		Share:			m.Coin.Share,
+		Challenge:		m.Coin.Challenge,
+		Response:		m.Coin.Response,
+		Signature:		m.Coin.Signature,
+	}, nil
+}
@@ -0,0 +1,23 @@
+package messages
+
+
+//Direct wraps a message which was received over a direct stream from its sender
+//instead of over the omni topic. omni passes it to its subscribers like any other message,
+//subscribers which don't care how a message arrived call Unwrap.
+type Direct struct{
+	Message
+}
+
+//Unwrap returns the message carried by in if in is Direct, otherwise in itself
+func Unwrap(in Message) Message{
+	if direct, ok := in.(Direct); ok{
+		return direct.Message
+	}
+	return in
+}
+
+//IsDirect tells whether in was received over a direct stream
+func IsDirect(in Message) bool{
+	_, ok := in.(Direct)
+	return ok
+}
@@ -0,0 +1,121 @@
+package messages
+
+import(
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	genmsg "distry/proto_gen/messages"
+)
+
+
+//MsgDkg is a message of the distributed key generation.
+//SHAREs travel over omni, DEALs, COMPLAINTs, ANSWERs and KEYs are the payloads of rbc0 rounds.
+type MsgDkg struct{
+	SenderID string;
+	Session uint64;
+	Type uint32;
+	RecipientID string;
+	Commitments, Share []byte;
+	Accused []string;
+	Reveals []DkgReveal;
+	Signature []byte;
+}
+
+//DkgReveal is the share a dealer sent to the recipient, revealed in answer to its complaint
+type DkgReveal struct{
+	RecipientID string;
+	Share []byte;
+}
+
+func (m MsgDkg) MarshalToProtobuf() *genmsg.Message{
+	reveals := make([]*genmsg.DkgReveal, len(m.Reveals))
+	for i, reveal := range m.Reveals{
+		reveals[i] = &genmsg.DkgReveal{RecipientId: reveal.RecipientID, Share: reveal.Share}
+	}
+	return &genmsg.Message{
+		Type: genmsg.Message_DKG,
+		Dkg: &genmsg.Dkg{
+			SenderId:		m.SenderID,
+			Session:			m.Session,
+			Type:				m.Type,
+			RecipientId:	m.RecipientID,
+			Commitments:	m.Commitments,
+			Share:			m.Share,
+			Accused:			m.Accused,
+			Reveals:			reveals,
+			Signature:		m.Signature,
+		},
+	}
+}
+
+func (m MsgDkg) Sender() string{
+	return m.SenderID
+}
+
+func (m MsgDkg) MessageType() genmsg.Message_Type{
+	return genmsg.Message_DKG
+}
+
+func (m MsgDkg) GetSignature() []byte{
+	return m.Signature
+}
+
+//the bytes the sender signs: every field of the message except the signature itself
+func (m MsgDkg) SigningBytes() []byte{
+	fields := []string{
+		m.SenderID,
+		strconv.FormatUint(m.Session, 10),
+		strconv.FormatUint(uint64(m.Type), 10),
+		m.RecipientID,
+		string(m.Commitments),
+		string(m.Share),
+		strconv.Itoa(len(m.Accused)),
+	}
+	fields = append(fields, m.Accused...)
+	for _, reveal := range m.Reveals{
+		fields = append(fields, reveal.RecipientID, string(reveal.Share))
+	}
+	return canonicalEncoding(genmsg.Message_DKG, fields...)
+}
+
+//SetSender and SetSignature let omni complete an outgoing message
+func (m *MsgDkg) SetSender(senderID string){
+	m.SenderID = senderID
+}
+
+func (m *MsgDkg) SetSignature(signature []byte){
+	m.Signature = signature
+}
+
+//MarshalDkg and UnmarshalDkg make up the codec of dkg messages, see Codec
+func MarshalDkg(msg SignedMessage) (*genmsg.Message, error){
+	switch m := msg.(type){
+		case MsgDkg:
+			return m.MarshalToProtobuf(), nil
+		case *MsgDkg:
+			return m.MarshalToProtobuf(), nil
+	}
+	return nil, errors.Errorf("%T is no dkg message", msg)
+}
+
+func UnmarshalDkg(m *genmsg.Message) (SignedMessage, error){
+	if m.Dkg == nil{
+		return nil, errMissingBody
+	}
+	reveals := make([]DkgReveal, len(m.Dkg.Reveals))
+	for i, reveal := range m.Dkg.Reveals{
+		reveals[i] = DkgReveal{RecipientID: reveal.RecipientId, Share: reveal.Share}
+	}
+	return MsgDkg{
+		SenderID:		m.Dkg.SenderId,
+		Session:			m.Dkg.Session,
+		Type:				m.Dkg.Type,
+		RecipientID:	m.Dkg.RecipientId,
+		Commitments:	m.Dkg.Commitments,
+		Share:			m.Dkg.Share,
+		Accused:			m.Dkg.Accused,
+		Reveals:			reveals,
+		Signature:		m.Dkg.Signature,
+	}, nil
+}
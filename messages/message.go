@@ -12,18 +12,21 @@ type Message interface{
 	MarshalToProtobuf() *genmsg.Message
 }
 
-func UnmarshalFromProtobuf(m *genmsg.Message) interface{} {
-	switch m.Type{
-		case genmsg.Message_RBC0:
-			return MsgRbc0{
-				SenderID:		m.Rbc0.SenderId,
-				ProtocolID:		m.Rbc0.ProtocolId,
-				Type:				m.Rbc0.Type,
-				Payload:			m.Rbc0.Payload,
-			}
-	}
-
-	return false;
+//SignedMessage is a message which carries the signature of its sender
+type SignedMessage interface{
+	Message
+	//MessageType returns the type MarshalToProtobuf sets, without marshalling the message
+	MessageType() genmsg.Message_Type
+	//Sender returns the ID of the node which claims to have sent the message
+	Sender() string
+	//SigningBytes returns the canonical encoding the signature is made over
+	SigningBytes() []byte
+	GetSignature() []byte
 }
 
-
+//Sealable is a signed message omni completes with the ID and signature of the sending node
+type Sealable interface{
+	SignedMessage
+	SetSender(string)
+	SetSignature([]byte)
+}
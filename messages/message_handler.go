@@ -1,7 +1,9 @@
 package messages
 
 import(
+	"context"
 	"sync"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 )
@@ -13,6 +15,9 @@ type Subscriber interface{
 
 	//Close closes the subscriber so it stops receiving new messages
 	Close()
+
+	//Dropped returns the number of messages dropped because the subscriber fell behind
+	Dropped() uint64
 }
 
 
@@ -23,17 +28,64 @@ type Publisher interface{
 
 	//Closed() check whether the publisher is closed
 	Closed() bool
+
+	//Dropped returns the number of messages dropped because the subscriber fell behind
+	Dropped() uint64
+}
+
+
+//OverflowPolicy decides what Publish does when the buffer of a subscription is full
+type OverflowPolicy int
+
+const(
+	//Block waits until the subscriber takes a message
+	Block OverflowPolicy = iota
+	//DropOldest discards the oldest buffered message to make room
+	DropOldest
+	//DropNewest discards the message being published
+	DropNewest
+)
+
+//SubscriptionConfig sets the buffer of a subscription and what happens once it is full
+type SubscriptionConfig struct{
+	Buffer	int //number of messages buffered, 0 makes every Publish wait for the subscriber
+	Policy	OverflowPolicy
+}
+
+//DefaultSubscriptionConfig is the config of the subscriptions protocols make to each other.
+//protocols can't lose messages, so the buffer only smooths out bursts. it blocks, so it is
+//no config for clients outside the node, see Fanout.
+func DefaultSubscriptionConfig() SubscriptionConfig{
+	return SubscriptionConfig{
+		Buffer:	256,
+		Policy:	Block,
+	}
 }
 
 
-//creates a new message subscription
+//creates a new unbuffered message subscription
 func NewSubscription() (Publisher, Subscriber){
-	c := make(chan Message)
+	return NewBufferedSubscription(SubscriptionConfig{})
+}
+
+//creates a new message subscription buffering cfg.Buffer messages
+func NewBufferedSubscription(cfg SubscriptionConfig) (Publisher, Subscriber){
+	if cfg.Buffer < 0{
+		cfg.Buffer = 0
+	}
+	if cfg.Buffer == 0{
+		//there is no buffer to drop messages from
+		cfg.Policy = Block
+	}
+	c := make(chan Message, cfg.Buffer)
 	doneC := make(chan struct{})
+	dropped := new(uint64)
 
 	pub := &publisher{
 		c:			c,
 		doneC:	doneC,
+		policy:	cfg.Policy,
+		dropped:	dropped,
 	}
 
 	//publisher must keep listening to doneC to see if subscriber has been closed
@@ -42,6 +94,7 @@ func NewSubscription() (Publisher, Subscriber){
 	sub := &subscriber{
 		c:			c,
 		doneC:	doneC,
+		dropped:	dropped,
 	}
 
 	return pub, sub
@@ -52,10 +105,17 @@ type subscriber struct{
 	c			<-chan Message
 	doneC		chan<- struct{}
 	closed	bool
+	dropped	*uint64 //shared with the publisher
+
+	//Close is usually called from another goroutine than the one blocked in Next
+	lock sync.Mutex
 }
 
 func (sub *subscriber) Next() (Message, error){
-	if sub.closed{
+	sub.lock.Lock()
+	closed := sub.closed
+	sub.lock.Unlock()
+	if closed{
 		return nil, errors.New("unable to receive next message: subscriber closed")
 	}
 
@@ -63,6 +123,12 @@ func (sub *subscriber) Next() (Message, error){
 }
 
 func (sub *subscriber) Close(){
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+	if sub.closed{
+		return
+	}
+
 	//signal we are done, so the owner of the sub.c can stop sending new messages
 	sub.doneC <- struct{}{}
 	close(sub.doneC)
@@ -70,24 +136,64 @@ func (sub *subscriber) Close(){
 	sub.closed = true
 }
 
+func (sub *subscriber) Dropped() uint64{
+	return atomic.LoadUint64(sub.dropped)
+}
+
 
 type publisher struct{
-	c			chan<- Message
+	//the publisher receives from c too, to drop the oldest message
+	c			chan Message
 	doneC		<-chan struct{}
 	closed	bool
+	policy	OverflowPolicy
+	dropped	*uint64
 
 	lock sync.RWMutex
 }
 
 func (pub *publisher) Publish(msg Message) error{
-	if pub.Closed() {
+	//hold the lock while sending, so handleClose can't close pub.c under our feet
+	pub.lock.RLock()
+	defer pub.lock.RUnlock()
+	if pub.closed {
 		return errors.New("unable to publish message: publisher closed")
 	}
 
+	//the buffer may have room although the subscriber is gone
 	select{
-		case pub.c <- msg:
 		case <-pub.doneC:
 			return errors.New("subscriber closed while publishing")
+		default:
+	}
+
+	switch pub.policy{
+		case DropNewest:
+			select{
+				case pub.c <- msg:
+				default:
+					atomic.AddUint64(pub.dropped, 1)
+			}
+		case DropOldest:
+			for{
+				select{
+					case pub.c <- msg:
+						return nil
+					default:
+				}
+				//the subscriber may take the oldest message first, then there is room anyway
+				select{
+					case <-pub.c:
+						atomic.AddUint64(pub.dropped, 1)
+					default:
+				}
+			}
+		default:
+			select{
+				case pub.c <- msg:
+				case <-pub.doneC:
+					return errors.New("subscriber closed while publishing")
+			}
 	}
 
 	return nil
@@ -100,6 +206,10 @@ func (pub *publisher) Closed() bool{
 	return pub.closed
 }
 
+func (pub *publisher) Dropped() uint64{
+	return atomic.LoadUint64(pub.dropped)
+}
+
 func (pub *publisher) handleClose(){
 	<-pub.doneC
 
@@ -108,3 +218,95 @@ func (pub *publisher) handleClose(){
 	pub.closed = true
 	close(pub.c)
 }
+
+
+//Forward publishes msg to every open publisher of pubs, as the messageForwarders of the
+//managers do. it returns the publishers still open, reusing pubs, and the number of
+//messages the subscribers dropped.
+func Forward(pubs []Publisher, msg Message) ([]Publisher, uint64){
+	open := pubs[:0]
+	var dropped uint64
+	for _, pub := range pubs{
+		before := pub.Dropped()
+		if pub.Closed() || pub.Publish(msg) != nil{
+			continue
+		}
+		dropped += pub.Dropped() - before
+		open = append(open, pub)
+	}
+	//let the closed publishers be collected
+	for i := len(open); i < len(pubs); i++{
+		pubs[i] = nil
+	}
+	return open, dropped
+}
+
+//Fanout hands the messages of a protocol manager to the subscribers of the manager.
+//the event loop of a manager publishes into an unbuffered subscription which Run reads.
+//Run waits for a Block subscriber whose buffer is full, and so does the event loop then:
+//Block is for the subscriptions managers make to each other, which keep up. Subscriptions of
+//clients outside the node must drop (DropOldest or DropNewest), so a stuck client can't stall the node.
+type Fanout struct{
+	pubs	[]Publisher
+	lock	sync.Mutex
+}
+
+//Subscribe adds a subscriber of the messages, buffered as cfg says
+func (f *Fanout) Subscribe(cfg SubscriptionConfig) Subscriber{
+	pub, sub := NewBufferedSubscription(cfg)
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.pubs = append(f.pubs, pub)
+
+	return sub
+}
+
+//Run forwards every message of sub to the subscribers and reports the number of messages
+//they dropped to dropped. it returns once sub is closed.
+func (f *Fanout) Run(sub Subscriber, dropped func(uint64)){
+	for{
+		msg, err := sub.Next()
+		if err != nil || msg == nil{
+			return
+		}
+
+		f.lock.Lock()
+		var n uint64
+		f.pubs, n = Forward(f.pubs, msg)
+		f.lock.Unlock()
+		dropped(n)
+	}
+}
+
+//WaitFor returns the first message of sub for which match is true, or ctx.Err() once ctx
+//ends first. subscribe before the message can be published, and close sub afterwards.
+func WaitFor(ctx context.Context, sub Subscriber, match func(Message) bool) (Message, error){
+	type next struct{
+		msg	Message
+		err	error
+	}
+	nextC := make(chan next, 1)
+	go func(){
+		for{
+			msg, err := sub.Next()
+			if err != nil{
+				nextC <- next{err: err}
+				return
+			}
+			if msg == nil{ //subscription was closed
+				return
+			}
+			if match(msg){
+				nextC <- next{msg: msg}
+				return
+			}
+		}
+	}()
+
+	select{
+		case n := <-nextC:
+			return n.msg, n.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+	}
+}
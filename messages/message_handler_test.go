@@ -0,0 +1,114 @@
+package messages
+
+import(
+	"context"
+	"testing"
+	"time"
+)
+
+//publish 1..5 into a subscription buffering 3 and return what the subscriber gets
+func overflow(t *testing.T, policy OverflowPolicy) ([]uint64, uint64){
+	t.Helper()
+	pub, sub := NewBufferedSubscription(SubscriptionConfig{Buffer: 3, Policy: policy})
+	defer sub.Close()
+
+	for seq := uint64(1); seq <= 5; seq++{
+		if err := pub.Publish(Rbc0Delivery{Seq: seq}); err != nil{
+			t.Fatal(err)
+		}
+	}
+	if pub.Dropped() != sub.Dropped(){
+		t.Fatalf("publisher dropped %d, subscriber %d", pub.Dropped(), sub.Dropped())
+	}
+
+	received := make([]uint64, 0, 3)
+	for i := 0; i < 3; i++{
+		msg, err := sub.Next()
+		if err != nil{
+			t.Fatal(err)
+		}
+		received = append(received, msg.(Rbc0Delivery).Seq)
+	}
+	return received, sub.Dropped()
+}
+
+func TestOverflowPolicies(t *testing.T){
+	for _, tc := range []struct{
+		name			string
+		policy		OverflowPolicy
+		received		[]uint64
+	}{
+		{"drop oldest", DropOldest, []uint64{3, 4, 5}},
+		{"drop newest", DropNewest, []uint64{1, 2, 3}},
+	}{
+		received, dropped := overflow(t, tc.policy)
+		if dropped != 2{
+			t.Fatalf("%s: dropped %d messages, expected 2", tc.name, dropped)
+		}
+		for i := range received{
+			if received[i] != tc.received[i]{
+				t.Fatalf("%s: received %v, expected %v", tc.name, received, tc.received)
+			}
+		}
+	}
+}
+
+func TestBlockPolicyUnblocksOnClose(t *testing.T){
+	pub, sub := NewBufferedSubscription(SubscriptionConfig{Buffer: 1, Policy: Block})
+	if err := pub.Publish(Rbc0Delivery{Seq: 1}); err != nil{
+		t.Fatal(err)
+	}
+
+	errC := make(chan error)
+	go func(){
+		errC <- pub.Publish(Rbc0Delivery{Seq: 2})
+	}()
+	sub.Close()
+	if err := <-errC; err == nil{
+		t.Fatal("publishing into a full buffer of a closed subscriber succeeded")
+	}
+}
+
+func TestForwardRemovesClosedPublishers(t *testing.T){
+	pubs := make([]Publisher, 3)
+	subs := make([]Subscriber, 3)
+	for i := range pubs{
+		pubs[i], subs[i] = NewBufferedSubscription(SubscriptionConfig{Buffer: 1, Policy: DropNewest})
+	}
+	subs[1].Close()
+
+	pubs, dropped := Forward(pubs, Rbc0Delivery{Seq: 1})
+	if len(pubs) != 2 || dropped != 0{
+		t.Fatalf("%d publishers left and %d dropped, expected 2 and 0", len(pubs), dropped)
+	}
+	//the buffers of both open subscribers are full now
+	if _, dropped = Forward(pubs, Rbc0Delivery{Seq: 2}); dropped != 2{
+		t.Fatalf("%d dropped, expected 2", dropped)
+	}
+}
+
+func TestWaitFor(t *testing.T){
+	var fanout Fanout
+	pub, sub := NewSubscription()
+	go fanout.Run(sub, func(uint64){})
+	defer sub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	waitSub := fanout.Subscribe(DefaultSubscriptionConfig())
+	defer waitSub.Close()
+	for seq := uint64(1); seq <= 3; seq++{
+		if err := pub.Publish(Rbc0Delivery{Seq: seq}); err != nil{
+			t.Fatal(err)
+		}
+	}
+
+	//the messages which don't match are skipped
+	msg, err := WaitFor(ctx, waitSub, func(msg Message) bool{ return msg.(Rbc0Delivery).Seq == 2 })
+	if err != nil || msg.(Rbc0Delivery).Seq != 2{
+		t.Fatalf("got %v, %v, want the delivery of Seq 2", msg, err)
+	}
+	if _, err = WaitFor(ctx, waitSub, func(Message) bool{ return false }); err != context.DeadlineExceeded{
+		t.Fatalf("got %v once the context ended", err)
+	}
+}
@@ -1,6 +1,11 @@
 package messages
 
 import(
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
 	genmsg "distry/proto_gen/messages"
 )
 
@@ -8,6 +13,7 @@ import(
 type MsgRbc0 struct{
 	Type uint32;
 	SenderID, ProtocolID, Payload string;
+	Signature []byte;
 }
 func (m MsgRbc0) MarshalToProtobuf() *genmsg.Message{
 	return &genmsg.Message{
@@ -17,6 +23,85 @@ func (m MsgRbc0) MarshalToProtobuf() *genmsg.Message{
 			ProtocolId:		m.ProtocolID,
 			Type:				m.Type,
 			Payload:			m.Payload,
+			Signature:		m.Signature,
+		},
+	}
+}
+
+func (m MsgRbc0) Sender() string{
+	return m.SenderID
+}
+
+func (m MsgRbc0) MessageType() genmsg.Message_Type{
+	return genmsg.Message_RBC0
+}
+
+func (m MsgRbc0) GetSignature() []byte{
+	return m.Signature
+}
+
+//the bytes the sender signs: every field of the message except the signature itself
+func (m MsgRbc0) SigningBytes() []byte{
+	return canonicalEncoding(
+		genmsg.Message_RBC0,
+		m.SenderID,
+		m.ProtocolID,
+		strconv.FormatUint(uint64(m.Type), 10),
+		m.Payload,
+	)
+}
+
+//SetSender and SetSignature let omni complete an outgoing message
+func (m *MsgRbc0) SetSender(senderID string){
+	m.SenderID = senderID
+}
+
+func (m *MsgRbc0) SetSignature(signature []byte){
+	m.Signature = signature
+}
+
+//MarshalRbc0 and UnmarshalRbc0 make up the codec of rbc0 messages, see Codec
+func MarshalRbc0(msg SignedMessage) (*genmsg.Message, error){
+	switch m := msg.(type){
+		case MsgRbc0:
+			return m.MarshalToProtobuf(), nil
+		case *MsgRbc0:
+			return m.MarshalToProtobuf(), nil
+	}
+	return nil, errors.Errorf("%T is no rbc0 message", msg)
+}
+
+func UnmarshalRbc0(m *genmsg.Message) (SignedMessage, error){
+	if m.Rbc0 == nil{
+		return nil, errMissingBody
+	}
+	return MsgRbc0{
+		SenderID:		m.Rbc0.SenderId,
+		ProtocolID:		m.Rbc0.ProtocolId,
+		Type:				m.Rbc0.Type,
+		Payload:			m.Rbc0.Payload,
+		Signature:		m.Rbc0.Signature,
+	}, nil
+}
+
+
+//Rbc0Delivery is handed by rbc0 to other parts of the node once a round is ACCEPTED.
+//it never leaves the node. It is marshalled as the '4':ACCEPTED stage of its round.
+type Rbc0Delivery struct{
+	Seq			uint64 //position of the delivery among all deliveries of this node, starts at 1
+	SenderID		string //ID of the node which INITed the round
+	ProtocolID	string
+	Payload		string //value the round was accepted with
+	AcceptedAt	time.Time
+}
+func (m Rbc0Delivery) MarshalToProtobuf() *genmsg.Message{
+	return &genmsg.Message{
+		Type: genmsg.Message_RBC0,
+		Rbc0: &genmsg.Rbc0{
+			SenderId:		m.SenderID,
+			ProtocolId:		m.ProtocolID,
+			Type:				4,
+			Payload:			m.Payload,
 		},
 	}
 }
@@ -0,0 +1,77 @@
+package messages
+
+import(
+	"sync"
+
+	"github.com/pkg/errors"
+
+	genmsg "distry/proto_gen/messages"
+)
+
+//Codec plugs a message type into the omni layer of a node. every protocol manager registers
+//the codec of the messages it exchanges with omni's Register, omni drops the messages of unregistered types.
+type Codec struct{
+	Type			genmsg.Message_Type
+	//Marshal maps an outgoing message of the type into protobuf
+	Marshal		func(SignedMessage) (*genmsg.Message, error)
+	//Unmarshal maps a received protobuf message of the type back, it must not trust any field of it
+	Unmarshal	func(*genmsg.Message) (SignedMessage, error)
+	//Handler gets the received messages of the type, wrapped in Direct if they came over a direct stream.
+	//omni calls it from a goroutine of the type, so it may block without holding up the other types
+	Handler		func(Message)
+}
+
+//Registry holds the codecs of a node, omni dispatches the received messages on it by type.
+//the handlers are the protocol managers of a node and a process can run several nodes
+//(the tests do), so every node has its own Registry.
+type Registry struct{
+	//map [ MESSAGE_TYPE -> codec ]
+	codecs	map[genmsg.Message_Type]Codec
+	lock		sync.RWMutex
+}
+
+func NewRegistry() *Registry{
+	return &Registry{codecs: make(map[genmsg.Message_Type]Codec)}
+}
+
+//Register adds the codec of a message type, registering a type twice panics
+func (r *Registry) Register(codec Codec){
+	if codec.Marshal == nil || codec.Unmarshal == nil || codec.Handler == nil{
+		panic("messages: codec of " + codec.Type.String() + " misses Marshal, Unmarshal or Handler")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, exists := r.codecs[codec.Type]; exists{
+		panic("messages: codec of " + codec.Type.String() + " registered twice")
+	}
+	r.codecs[codec.Type] = codec
+}
+
+//Lookup returns the codec registered for t
+func (r *Registry) Lookup(t genmsg.Message_Type) (Codec, bool){
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	codec, exists := r.codecs[t]
+	return codec, exists
+}
+
+//Marshal maps msg into protobuf with the codec registered for its type
+func (r *Registry) Marshal(msg SignedMessage) (*genmsg.Message, error){
+	codec, exists := r.Lookup(msg.MessageType())
+	if !exists{
+		return nil, errors.Errorf("no codec registered for %T", msg)
+	}
+	return codec.Marshal(msg)
+}
+
+//Unmarshal maps m back into a message with the codec registered for its type
+func (r *Registry) Unmarshal(m *genmsg.Message) (SignedMessage, error){
+	codec, exists := r.Lookup(m.Type)
+	if !exists{
+		return nil, errors.Errorf("no codec registered for message type %s", m.Type.String())
+	}
+	return codec.Unmarshal(m)
+}
+
+//errMissingBody is returned by the unmarshal functions when the body of the message type is not set
+var errMissingBody = errors.New("message body missing")
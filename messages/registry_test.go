@@ -0,0 +1,13 @@
+package messages
+
+import(
+	"testing"
+)
+
+func TestMessageType(t *testing.T){
+	for _, msg := range []SignedMessage{MsgRbc0{}, MsgAvid{}, MsgCbc{}, MsgAba{}, MsgCoin{}, MsgDkg{}}{
+		if msg.MessageType() != msg.MarshalToProtobuf().Type{
+			t.Fatalf("%T has the type %s, marshals into %s", msg, msg.MessageType(), msg.MarshalToProtobuf().Type)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package messages
+
+import(
+	"encoding/binary"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+
+	genmsg "distry/proto_gen/messages"
+)
+
+//canonicalEncoding is the byte string over which messages are signed.
+//it is independent of the protobuf encoding, so signatures survive (un)marshalling.
+//the message type comes first so a signature for one message type can never be
+//replayed as a signature for another. Every field is prefixed by its length,
+//so no two different lists of fields map to the same encoding.
+func canonicalEncoding(msgType genmsg.Message_Type, fields ...string) []byte{
+	buf := make([]byte, 0, 64)
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+
+	n := binary.PutUvarint(lenBuf, uint64(msgType))
+	buf = append(buf, lenBuf[:n]...)
+	for _, field := range fields{
+		n = binary.PutUvarint(lenBuf, uint64(len(field)))
+		buf = append(buf, lenBuf[:n]...)
+		buf = append(buf, field...)
+	}
+
+	return buf
+}
+
+//Sign signs data with the private key of the node
+func Sign(privKey crypto.PrivKey, data []byte) ([]byte, error){
+	if privKey == nil{
+		return nil, errors.New("no private key to sign with")
+	}
+	return privKey.Sign(data)
+}
+
+//VerifySignature checks that signature over data was made by the owner of senderID.
+//the public key is extracted from the peer ID itself (Ed25519 IDs embed it),
+//so no key distribution is needed.
+func VerifySignature(senderID string, data, signature []byte) error{
+	if len(signature) == 0{
+		return errors.New("message is not signed")
+	}
+
+	id, err := peer.Decode(senderID)
+	if err != nil{
+		return errors.Wrap(err, "decoding sender ID")
+	}
+	pubKey, err := id.ExtractPublicKey()
+	if err != nil{
+		return errors.Wrap(err, "extracting public key from sender ID")
+	}
+
+	ok, err := pubKey.Verify(data, signature)
+	if err != nil{
+		return errors.Wrap(err, "verifying signature")
+	}
+	if !ok{
+		return errors.New("signature does not match sender")
+	}
+
+	return nil
+}
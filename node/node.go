@@ -7,8 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	_"strconv"
-	_"strings"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -25,6 +24,14 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"distry/aba"
+	"distry/acs"
+	"distry/avid"
+	"distry/cbc"
+	"distry/coin"
+	"distry/dkg"
+	"distry/membership"
+	"distry/messages"
 	"distry/omni"
 	"distry/rbc0"
 
@@ -33,8 +40,16 @@ import (
 const (
 	discoveryNamespace	= "reconquista"
 	privKeyFileName		= "mojkljuc.privkey"
+	coinKeysFileName		= "mojkljuc.coin" //read from the directory of the private key
 )
 
+//ErrUnknownRound is returned when a round is polled which this node has never seen
+var ErrUnknownRound = errors.New("unknown round")
+
+//ErrNoCoinKeys is returned by the aba and acs calls of a node which has no threshold coin keys yet,
+//they are generated by RunDKG and used from the next start of the node
+var ErrNoCoinKeys = errors.New("no coin keys, generate them with dkg and restart the node")
+
 type Node interface{
 	//INTERNAL
 	ID() peer.ID
@@ -46,10 +61,17 @@ type Node interface{
 
 	getPrivKey() (string, error)
 	sign(string, []byte) ([]byte, error)
-	verify(interface{}) bool
 
 	//RPCS
-	Rbc0(message string) (bool, error)
+	Rbc0(ctx context.Context, message string) (messages.Rbc0Delivery, error)
+	GetRbc0Round(protocolID string) (rbc0.RoundStatus, error)
+	SubscribeDeliveries(fromSeq uint64) ([]messages.Rbc0Delivery, messages.Subscriber, error)
+	Avid(ctx context.Context, payload []byte) (messages.AvidDelivery, error)
+	Cbc(ctx context.Context, payload string) (messages.CbcDelivery, error)
+	Agree(ctx context.Context, sessionID string, bit bool) (messages.AbaDecision, error)
+	ProposeBatch(ctx context.Context, epoch uint64, payload string) (messages.AcsOutput, error)
+	SubscribeEpochOutputs() (messages.Subscriber, error)
+	RunDKG(ctx context.Context, session uint64) (dkg.Result, error)
 }
 
 type node struct{
@@ -62,12 +84,23 @@ type node struct{
 	multiaddrLock sync.RWMutex
 
 	privKey crypto.PrivKey
+	keyDir string //directory the private key was read from
 	peersNum int //number of peers found in the network
 
 	bootstrapOnly bool
+	hashCoin bool //whether aba runs on HashCoin when there are no coin keys
+	rbc0Cfg rbc0.Config
+	dkgCfg dkg.Config
 
 	omniManager *omni.Manager
+	membershipManager *membership.Manager
 	rbc0Manager *rbc0.Manager
+	avidManager *avid.Manager
+	cbcManager *cbc.Manager
+	coinManager *coin.Manager
+	abaManager *aba.Manager
+	acsManager *acs.Manager
+	dkgManager *dkg.Manager
 
 }
 
@@ -189,69 +222,10 @@ func (n *node) sign(ownerID string, msg []byte) ([]byte, error){
 */
 }
 
-func (n *node) verify(message interface{}) bool{
-	return false;
-/*
-	switch msg := message.(type){
-		case messages.RetrievalRequest:
-			ownerID, err := peer.Decode(msg.OwnerID)
-			if err != nil{
-				n.logger.Debug("cannot verify message with invalid owner ID")
-				return false
-			}
-			pubKey, err := ownerID.ExtractPublicKey()
-			if err != nil{
-				n.logger.Debug("pubkey extraction from owner ID failed")
-				return false
-			}
-
-			str := msg.Multiaddr + msg.OwnerID + strconv.FormatInt(msg.Timestamp.Unix(), 10)
-			ver, err := pubKey.Verify([]byte(str), []byte(msg.Signature))
-			if err != nil{
-				n.logger.Debug("error during signature verification")
-				return false
-			}
-			return ver
-
-		case messages.Offer:
-			components := strings.Split(msg.Multiaddr, "/")
-			ownerID, err := peer.Decode(components[len(components)-1])
-			fmt.Printf("\n\nthis is ownerID:   '%v'\n\n", ownerID)
-			if err != nil{
-				n.logger.Debug("cannot verify message with invalid owner ID")
-				return false
-			}
-			pubKey, err := ownerID.ExtractPublicKey()
-			if err != nil{
-				n.logger.Debug("pubkey extraction from owner ID failed")
-				return false
-			}
-
-			str := msg.Multiaddr + strconv.FormatUint(uint64(msg.Capacity), 10) + strconv.FormatInt(msg.Timestamp.Unix(), 10)
-			str = "kek"
-			fmt.Printf("\n\nthis is offer:  %v\n\n", msg)
-			fmt.Printf("\n\nthis is offer str:  %v\n\n", str)
-			fmt.Printf("\n\nthis is offer signature:  %v\n\n", msg.Signature)
-			ver, err := pubKey.Verify([]byte(str), []byte(msg.Signature))
-			if err != nil{
-				n.logger.Debug("error during signature verification")
-				return false
-			}
-			n.logger.Debug("okej ampak zdej sm tuki")
-			fmt.Printf("picku mater nooooo     %v\n\n", ver)
-			return ver
-
-		default:
-			n.logger.Debug("cannot verify unknown message type")
-			return false
-	}
-*/
-}
-
 //---------------------------</HELPERS>
 //---------------------------<SETUP>
 
-func NewNode(logger *zap.Logger, bootstrapOnly bool) Node{
+func NewNode(logger *zap.Logger, bootstrapOnly, hashCoin bool, rbc0Cfg rbc0.Config, dkgCfg dkg.Config) Node{
 	if logger == nil{
 		logger = zap.NewNop()
 	}
@@ -260,6 +234,9 @@ func NewNode(logger *zap.Logger, bootstrapOnly bool) Node{
 		logger:			logger,
 		host:				nil,
 		bootstrapOnly:	bootstrapOnly,
+		hashCoin:		hashCoin,
+		rbc0Cfg:			rbc0Cfg,
+		dkgCfg:			dkgCfg,
 		peersNum:		0,
 	}
 }
@@ -289,6 +266,10 @@ func (n *node) Start(ctx context.Context, port uint16, pkFileName string) error{
 	}
 	n.host = host
 	n.privKey = privKey
+	n.keyDir = "."
+	if pkFileName != ""{
+		n.keyDir = filepath.Dir(pkFileName)
+	}
 
 	n.logger.Debug("creating pubsub")
 	ps, err := pubsub.NewGossipSub(ctx, n.host, pubsub.WithMessageSignaturePolicy(pubsub.StrictSign))
@@ -348,12 +329,19 @@ func (n *node) Bootstrap(ctx context.Context, nodeAddrs []multiaddr.Multiaddr) e
 	}
 
 	n.logger.Debug("setting up Manager")
-	omniManager, err := omni.NewManager(n.logger, n.ID(), n.kadDHT, n.ps)
+	omniManager, err := omni.NewManager(n.logger, n.ID(), n.privKey, n.host, n.kadDHT, n.ps)
 	if err != nil{
 		return err
 	}
 	n.omniManager = omniManager
 
+	n.logger.Debug("creating MembershipManager")
+	membershipManager, err := membership.NewManager(n.logger, n.omniManager)
+	if err != nil{
+		return err
+	}
+	n.membershipManager = membershipManager
+
 	if len(nodeAddrs) == 0{
 		return nil
 	}
@@ -409,13 +397,110 @@ func (n *node) Bootstrap(ctx context.Context, nodeAddrs []multiaddr.Multiaddr) e
 	}()
 
 	n.logger.Debug("creating Rbc0Manager")
-	rbc0Manager := rbc0.NewManager(n.logger, n.peersNum, n.omniManager)
+	rbc0Manager := rbc0.NewManager(n.logger, n.rbc0Cfg, n.membershipManager, n.omniManager)
 	n.rbc0Manager = rbc0Manager
 	n.logger.Debug("creating Rbc0Manager: DONE")
 
+	n.logger.Debug("creating AvidManager")
+	n.avidManager = avid.NewManager(n.logger, n.rbc0Cfg, n.membershipManager, n.omniManager)
+
+	n.logger.Debug("creating CbcManager")
+	n.cbcManager = cbc.NewManager(n.logger, n.rbc0Cfg, n.privKey, n.membershipManager, n.omniManager)
+
+	//aba and acs need the threshold coin, whose keys a dkg session generates.
+	//until the node is restarted with them, it only takes part in dkg
+	var abaCoin aba.Coin
+	coinKeys, err := coin.LoadKeys(filepath.Join(n.keyDir, coinKeysFileName))
+	if err == nil{
+		n.logger.Debug("creating CoinManager")
+		n.coinManager, err = coin.NewManager(n.logger, n.rbc0Cfg, coinKeys, n.omniManager)
+		if err != nil{
+			return errors.Wrap(err, "creating CoinManager")
+		}
+		abaCoin = n.coinManager
+	} else if !os.IsNotExist(err){
+		return errors.Wrap(err, "loading coin keys")
+	} else if n.hashCoin{
+		n.logger.Warn("no coin keys found, aba uses a predictable coin",
+			zap.String("file", filepath.Join(n.keyDir, coinKeysFileName)),
+		)
+		abaCoin = aba.HashCoin{}
+	} else{
+		n.logger.Warn("no coin keys found, aba and acs are off until dkg generates them and the node restarts",
+			zap.String("file", filepath.Join(n.keyDir, coinKeysFileName)),
+		)
+	}
+
+	if abaCoin != nil{
+		n.logger.Debug("creating AbaManager")
+		n.abaManager, err = aba.NewManager(n.logger, n.rbc0Cfg, abaCoin, n.membershipManager, n.omniManager)
+		if err != nil{
+			return errors.Wrap(err, "creating AbaManager")
+		}
+
+		n.logger.Debug("creating AcsManager")
+		n.acsManager = acs.NewManager(n.logger, n.rbc0Cfg, n.membershipManager, n.rbc0Manager, n.abaManager)
+	}
+
+	//dkg agrees on the qualified dealers with aba. Before dkg generated the coin keys, it has
+	//to agree with the predictable coin: an adversary which controls the network scheduling can
+	//keep its agreements from deciding, and so fail the session, but not make nodes disagree
+	dkgAgreement := n.abaManager
+	if dkgAgreement == nil{
+		dkgAgreement, err = aba.NewManager(n.logger, n.rbc0Cfg, aba.HashCoin{}, n.membershipManager, n.omniManager)
+		if err != nil{
+			return errors.Wrap(err, "creating AbaManager of dkg")
+		}
+	}
+
+	n.logger.Debug("creating DkgManager")
+	n.dkgManager, err = dkg.NewManager(n.logger, n.rbc0Cfg, n.dkgCfg, n.privKey, n.membershipManager, n.omniManager, n.rbc0Manager, dkgAgreement)
+	if err != nil{
+		return errors.Wrap(err, "creating DkgManager")
+	}
+	go n.storeDkgResults(n.dkgManager.SubscribeToMessages())
+
 	return nil
 }
 
+//store the keys generated by the dkg sessions this node takes part in next to its private key.
+//the keys of the first session become the coin keys, which the coin uses from the next start of the node.
+//a later session never replaces them, its keys are stored in a file of their own.
+func (n *node) storeDkgResults(sub messages.Subscriber){
+	for{
+		msg, err := sub.Next()
+		if err != nil{
+			n.logger.Error("failed receiving dkg result", zap.Error(err))
+			continue
+		}
+
+		result, ok := msg.(dkg.Result)
+		if !ok || result.Err != nil{
+			continue
+		}
+		fileName := filepath.Join(n.keyDir, coinKeysFileName)
+		err = result.Keys.Save(fileName)
+		if errors.Is(err, os.ErrExist){
+			fileName = fmt.Sprintf("%s.%d", fileName, result.Session)
+			if err = result.Keys.Save(fileName); err == nil{
+				n.logger.Warn("coin keys exist, stored the keys generated by dkg aside, move them in place to use them",
+					zap.Uint64("session", result.Session),
+					zap.String("file", fileName),
+				)
+				continue
+			}
+		}
+		if err != nil{
+			n.logger.Error("failed storing dkg result", zap.Uint64("session", result.Session), zap.Error(err))
+			continue
+		}
+		n.logger.Info("stored coin keys generated by dkg",
+			zap.Uint64("session", result.Session),
+			zap.String("file", fileName),
+		)
+	}
+}
+
 
 func (n *node) Shutdown() error{
 	return n.host.Close()
@@ -424,15 +509,108 @@ func (n *node) Shutdown() error{
 //---------------------------</SETUP>
 //---------------------------<RPC>
 
-func (n *node) Rbc0(payload string) (bool, error){
+//returns the delivery of the round, or a *rbc0.NotAcceptedError if ctx ends
+//before the round is accepted
+func (n *node) Rbc0(ctx context.Context, payload string) (messages.Rbc0Delivery, error){
+	if n.bootstrapOnly{
+		return messages.Rbc0Delivery{}, errors.New("can't send message on a bootstrap-only node")
+	}
+
+	return n.rbc0Manager.Broadcast(ctx, payload)
+}
+
+func (n *node) GetRbc0Round(protocolID string) (rbc0.RoundStatus, error){
 	if n.bootstrapOnly{
-		return false, errors.New("can't send message on a bootstrap-only node")
+		return rbc0.RoundStatus{}, errors.New("bootstrap-only node takes no part in rbc0 rounds")
 	}
 
-	return n.rbc0Manager.Broadcast(n.ID().Pretty(), payload)
+	status, exists := n.rbc0Manager.RoundStatus(protocolID)
+	if !exists{
+		return rbc0.RoundStatus{}, ErrUnknownRound
+	}
+	return status, nil
 }
 
+//returns the logged deliveries from fromSeq on and a subscription to the upcoming ones
+func (n *node) SubscribeDeliveries(fromSeq uint64) ([]messages.Rbc0Delivery, messages.Subscriber, error){
+	if n.bootstrapOnly{
+		return nil, nil, errors.New("bootstrap-only node takes no part in rbc0 rounds")
+	}
 
+	replay, sub := n.rbc0Manager.SubscribeDeliveries(fromSeq)
+	return replay, sub, nil
+}
+
+
+//disperses payload with avid, returns the delivery of the round,
+//or a *rbc0.NotAcceptedError if ctx ends before the round is accepted
+func (n *node) Avid(ctx context.Context, payload []byte) (messages.AvidDelivery, error){
+	if n.bootstrapOnly{
+		return messages.AvidDelivery{}, errors.New("can't send message on a bootstrap-only node")
+	}
+
+	return n.avidManager.Broadcast(ctx, payload)
+}
+
+//broadcasts payload with signed-echo consistent broadcast, returns the delivery of the round,
+//or a *rbc0.NotAcceptedError if ctx ends before the round is delivered
+func (n *node) Cbc(ctx context.Context, payload string) (messages.CbcDelivery, error){
+	if n.bootstrapOnly{
+		return messages.CbcDelivery{}, errors.New("can't send message on a bootstrap-only node")
+	}
+
+	return n.cbcManager.Broadcast(ctx, payload)
+}
+
+//gives bit as this node's input to the agreement sessionID, returns the decision,
+//or ctx.Err() if ctx ends before the session decides
+func (n *node) Agree(ctx context.Context, sessionID string, bit bool) (messages.AbaDecision, error){
+	if n.bootstrapOnly{
+		return messages.AbaDecision{}, errors.New("bootstrap-only node takes no part in agreements")
+	}
+	if n.abaManager == nil{
+		return messages.AbaDecision{}, ErrNoCoinKeys
+	}
+
+	return n.abaManager.Agree(ctx, sessionID, bit)
+}
+
+//proposes payload in the acs epoch, returns the output of the epoch,
+//or ctx.Err() if ctx ends before the epoch is over
+func (n *node) ProposeBatch(ctx context.Context, epoch uint64, payload string) (messages.AcsOutput, error){
+	if n.bootstrapOnly{
+		return messages.AcsOutput{}, errors.New("can't send message on a bootstrap-only node")
+	}
+	if n.acsManager == nil{
+		return messages.AcsOutput{}, ErrNoCoinKeys
+	}
+
+	return n.acsManager.ProposeBatch(ctx, epoch, payload)
+}
+
+//returns a subscription to the outputs of the acs epochs
+func (n *node) SubscribeEpochOutputs() (messages.Subscriber, error){
+	if n.bootstrapOnly{
+		return nil, errors.New("bootstrap-only node takes no part in acs epochs")
+	}
+	if n.acsManager == nil{
+		return nil, ErrNoCoinKeys
+	}
+
+	//a client which falls behind loses the oldest outputs instead of stalling the node,
+	//the gap shows in the epochs
+	return n.acsManager.Subscribe(messages.SubscriptionConfig{Buffer: n.rbc0Cfg.DeliveryBuffer, Policy: messages.DropOldest}), nil
+}
+
+//runs the dkg session, returns its result once the keys are generated,
+//or ctx.Err() if ctx ends before the session is over
+func (n *node) RunDKG(ctx context.Context, session uint64) (dkg.Result, error){
+	if n.bootstrapOnly{
+		return dkg.Result{}, errors.New("bootstrap-only node takes no part in key generation")
+	}
+
+	return n.dkgManager.RunDKG(ctx, session)
+}
 
 
 
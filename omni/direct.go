@@ -0,0 +1,140 @@
+package omni
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"distry/messages"
+)
+
+//messages meant for a single peer don't need to flood the omni topic,
+//they are sent over a libp2p stream of directProtocol instead.
+//a stream carries frames of a uvarint length followed by a marshalled genmsg.Message,
+//signed the same way as omni messages.
+const (
+	directProtocol = protocol.ID("/reconquista/direct/1.0.0")
+	maxFrameSize = 16 << 20
+	directTimeout = 10*time.Second
+)
+
+//SendTo signs msg like OmniPublisher does and sends it to peerID only.
+//the receiver hands it to its subscribers wrapped in messages.Direct.
+func (m *Manager) SendTo(peerID peer.ID, msg messages.Message) error{
+	if peerID == m.NodeID{
+		return errors.New("sending direct message to self")
+	}
+	out, err := m.seal(msg)
+	if err != nil{
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), directTimeout)
+	defer cancel()
+	stream, err := m.host.NewStream(ctx, peerID, directProtocol)
+	if err != nil{
+		m.logger.Warn("failed opening direct stream", zap.String("peer", peerID.String()), zap.Error(err))
+		return errors.Wrap(err, "opening direct stream")
+	}
+	_ = stream.SetWriteDeadline(time.Now().Add(directTimeout))
+
+	if err := writeFrame(stream, out); err != nil{
+		_ = stream.Reset()
+		m.logger.Warn("failed sending direct message", zap.String("peer", peerID.String()), zap.Error(err))
+		return errors.Wrap(err, "sending direct message")
+	}
+	return stream.Close()
+}
+
+//SendOrPublish sends msg to peerID like SendTo, off the calling goroutine since opening a stream
+//may take a while. If msg can't be sent over a direct stream, it is published on the omni topic
+//instead, where peerID finds it among the messages of everyone.
+func (m *Manager) SendOrPublish(peerID peer.ID, msg messages.Message){
+	go func(){
+		err := m.SendTo(peerID, msg)
+		if err == nil{
+			return
+		}
+		m.logger.Debug("sending direct message FAILED, publishing it", zap.String("peer", peerID.String()), zap.Error(err))
+		if err := m.OmniPublisher(msg); err != nil{
+			m.logger.Error("publishing direct message FAILED", zap.String("peer", peerID.String()), zap.Error(err))
+		}
+	}()
+}
+
+//read frames off a direct stream until the sender closes it
+func (m *Manager) handleDirectStream(stream network.Stream){
+	defer stream.Close()
+	remote := stream.Conn().RemotePeer()
+	reader := bufio.NewReader(stream)
+
+	for{
+		_ = stream.SetReadDeadline(time.Now().Add(directTimeout))
+		data, err := readFrame(reader)
+		if err == io.EOF{
+			return
+		} else if err != nil{
+			m.logger.Warn("failed reading direct message", zap.String("peer", remote.String()), zap.Error(err))
+			_ = stream.Reset()
+			return
+		}
+
+		msg, err := m.open(data)
+		if err != nil{
+			m.logger.Warn("dropping direct message", zap.String("peer", remote.String()), zap.Error(err))
+			continue
+		}
+		//the stream is authenticated, a peer only sends its own messages over it
+		if msg.Sender() != remote.String(){
+			m.logger.Warn("dropping direct message relayed by another peer",
+				zap.String("senderID", msg.Sender()),
+				zap.String("peer", remote.String()),
+			)
+			continue
+		}
+
+		if err := m.receivedPublisher.Publish(messages.Direct{Message: msg}); err != nil{
+			m.logger.Error("failed passing direct message to messageForwarder", zap.Error(err))
+		}
+	}
+}
+
+func writeFrame(w io.Writer, data []byte) error{
+	if len(data) > maxFrameSize{
+		return errors.Errorf("frame of %d bytes exceeds %d", len(data), maxFrameSize)
+	}
+	var prefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(prefix[:], uint64(len(data)))
+	if _, err := w.Write(prefix[:n]); err != nil{
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+//readFrame returns io.EOF only if the stream ended between frames
+func readFrame(r *bufio.Reader) ([]byte, error){
+	size, err := binary.ReadUvarint(r)
+	if err != nil{
+		return nil, err
+	}
+	if size > maxFrameSize{
+		return nil, errors.Errorf("frame of %d bytes exceeds %d", size, maxFrameSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil{
+		if err == io.EOF{
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return data, nil
+}
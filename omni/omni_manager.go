@@ -2,11 +2,14 @@ package omni
 
 import (
 	"context"
+	"expvar"
 	_"fmt"
 	_"math"
 	"sync"
 	_"time"
 
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -21,27 +24,41 @@ const (
 	topicName = "reconquista_omni"
 )
 
+//counts of the node's omni traffic, served by expvar under /debug/vars
+var metrics = expvar.NewMap("omni")
+
 
 //Manager manages omnidisk traffic through the pubsub and implements operations
 type Manager struct{
 	logger		*zap.Logger
 	NodeID		peer.ID
+	privKey		crypto.PrivKey //used to sign every outgoing message
+	host			host.Host //direct streams to single peers are opened on it, see SendTo
 	kadDHT		*dht.IpfsDHT
 
 	ps					*pubsub.PubSub
 	subscription	*pubsub.Subscription
 	topic				*pubsub.Topic
 
+	//codecs of the message types the protocol managers of the node registered
+	codecs					*messages.Registry
+	//omniReceiver and the direct stream handlers pass messages via receivedPublisher to messageForwarder
+	receivedPublisher		messages.Publisher
 	msgPublishers			[]messages.Publisher
+	//map [ MESSAGE_TYPE -> publisher of the dispatch of the type's handler ]
+	typePublishers			map[genmsg.Message_Type]messages.Publisher
 	msgPublishersLock		sync.RWMutex
 
 }
 
 
 //---------------------------<HELPERS>
+func (m *Manager) ID() peer.ID{
+	return m.NodeID
+}
 //---------------------------</HELPERS>
 //---------------------------<SETUP>
-func NewManager(logger *zap.Logger, nodeID peer.ID, kadDHT *dht.IpfsDHT, ps *pubsub.PubSub) (*Manager, error){
+func NewManager(logger *zap.Logger, nodeID peer.ID, privKey crypto.PrivKey, h host.Host, kadDHT *dht.IpfsDHT, ps *pubsub.PubSub) (*Manager, error){
 	if logger == nil{
 		logger = zap.NewNop()
 	}
@@ -50,15 +67,24 @@ func NewManager(logger *zap.Logger, nodeID peer.ID, kadDHT *dht.IpfsDHT, ps *pub
 	m := &Manager{
 		logger:				logger,
 		NodeID:				nodeID,
+		privKey:				privKey,
+		host:					h,
 		ps:					ps,
 		kadDHT:				kadDHT,
+		codecs:				messages.NewRegistry(),
 		msgPublishers:		make([]messages.Publisher, 0),
+		typePublishers:	make(map[genmsg.Message_Type]messages.Publisher),
 	}
 
+	pub, sub := messages.NewSubscription()
+	m.receivedPublisher = pub
+	go m.messageForwarder(sub)
+
 	if err := m.joinOmniNet(); err != nil{
 		m.logger.Error("failed joining omni network")
 		return nil, err
 	}
+	m.host.SetStreamHandler(directProtocol, m.handleDirectStream)
 
 	return m, nil
 }
@@ -100,23 +126,12 @@ func (m *Manager) joinOmniNet() error{
 
 //---------------------------</SETUP>
 //input: msg
-//complete it (with SenderID or Signature etc..),
+//complete it (with SenderID and Signature),
 //then marshal & publish to omni network
 func (m *Manager) OmniPublisher(msg messages.Message) error{
-	var pb *genmsg.Message
-	switch msg.(type){
-		case *messages.MsgRbc0:
-			rbc0 := msg.(*messages.MsgRbc0)
-			(*rbc0).SenderID = m.NodeID.String()
-			pb = (*rbc0).MarshalToProtobuf()
-		default:
-			m.logger.Error("trying to omni-publish foreign msg type")
-			return errors.New("foreign msg type")
-	}
-	out, err := pb.Marshal()
+	out, err := m.seal(msg)
 	if err != nil{
-		m.logger.Error("failed marshalling omni message", zap.Error(err))
-		return errors.Wrap(err, "marshalling omni message")
+		return err
 	}
 
 	if err := m.topic.Publish(context.Background(), out); err != nil{
@@ -127,42 +142,87 @@ func (m *Manager) OmniPublisher(msg messages.Message) error{
 	return nil
 }
 
+//complete msg with SenderID and Signature and marshal it with the codec of its type
+func (m *Manager) seal(msg messages.Message) ([]byte, error){
+	sealable, ok := msg.(messages.Sealable)
+	if !ok{
+		m.logger.Error("trying to omni-publish foreign msg type")
+		return nil, errors.Errorf("foreign msg type %T", msg)
+	}
+	sealable.SetSender(m.NodeID.String())
+	signature, err := messages.Sign(m.privKey, sealable.SigningBytes())
+	if err != nil{
+		m.logger.Error("failed signing omni message", zap.Error(err))
+		return nil, errors.Wrap(err, "signing omni message")
+	}
+	sealable.SetSignature(signature)
+
+	pb, err := m.codecs.Marshal(sealable)
+	if err != nil{
+		m.logger.Error("trying to omni-publish unregistered msg type", zap.Error(err))
+		return nil, err
+	}
+	out, err := pb.Marshal()
+	if err != nil{
+		m.logger.Error("failed marshalling omni message", zap.Error(err))
+		return nil, errors.Wrap(err, "marshalling omni message")
+	}
+
+	return out, nil
+}
+
 //receive messages from omni network, process them a bit (verify signature)
 //pass them to messageForwarder which will dispatch them to other parts of the node
 func (m *Manager) omniReceiver(){
-	pub, sub := messages.NewSubscription()
-	go m.messageForwarder(sub)
-
 	for{
 		//m.logger.Debug("received omni msg")
 		omniMsg, err := m.subscription.Next(context.Background())
 		if err != nil{
 			m.logger.Error("failed receiving omni message", zap.Error(err))
+			continue
 		}
 		if omniMsg.ReceivedFrom == m.NodeID{
 			continue
 		}
-		/*
-		*/
 
-		in := genmsg.Message{}
-		if err := in.Unmarshal(omniMsg.Data); err != nil{
-			m.logger.Warn("cannot unmarshal omni message. Ignoring", zap.Error(err))
-			continue;
+		msg, err := m.open(omniMsg.Data)
+		if err != nil{
+			m.logger.Warn("dropping omni message",
+				zap.String("receivedFrom", omniMsg.ReceivedFrom.String()),
+				zap.Error(err),
+			)
+			continue
 		}
 
-		//TODO
-		//message signature will come into play here
-
-		msg := messages.UnmarshalFromProtobuf(&in).(messages.MsgRbc0)
-
-		if err := pub.Publish(msg); err != nil{
+		if err := m.receivedPublisher.Publish(msg); err != nil{
 			m.logger.Error("failed passing omni message to messageForwarder", zap.Error(err))
 		}
 	}
 }
 
-//forward messages received from omni network to other parts of the node (like rbc0)
+//unmarshal a received message and check its signature.
+//anyone can put any SenderID into a message,
+//so only pass on messages actually signed by the node they claim to come from
+func (m *Manager) open(data []byte) (messages.SignedMessage, error){
+	in := genmsg.Message{}
+	if err := in.Unmarshal(data); err != nil{
+		return nil, errors.Wrap(err, "unmarshalling message")
+	}
+
+	//messages of types no protocol of this node registered are dropped here
+	msg, err := m.codecs.Unmarshal(&in)
+	if err != nil{
+		return nil, err
+	}
+
+	if err := messages.VerifySignature(msg.Sender(), msg.SigningBytes(), msg.GetSignature()); err != nil{
+		return nil, errors.Wrapf(err, "invalid signature of %s", msg.Sender())
+	}
+	return msg, nil
+}
+
+//forward messages received from omni network to other parts of the node (like rbc0).
+//a message goes to the handler of its type and to the subscribers of all messages.
 func (m *Manager) messageForwarder(sub messages.Subscriber){
 	for{
 		msg, err := sub.Next()
@@ -170,23 +230,37 @@ func (m *Manager) messageForwarder(sub messages.Subscriber){
 			m.logger.Error("failed receiving msg from omniReceiver", zap.Error(err))
 			continue
 		}
+		signed, ok := messages.Unwrap(msg).(messages.SignedMessage)
+		if !ok{
+			m.logger.Error("dropping unsigned message in messageForwarder")
+			continue
+		}
+		msgType := signed.MessageType()
 
 		m.msgPublishersLock.Lock()
-		for _, pub := range m.msgPublishers{
-			if pub.Closed(){
-				continue
-			} else if err := pub.Publish(msg); err != nil{
-				m.logger.Error("failed forwarding message", zap.Error(err))
-			}
+		var typeDropped, dropped uint64
+		if pub, exists := m.typePublishers[msgType]; exists{
+			_, typeDropped = messages.Forward([]messages.Publisher{pub}, msg)
 		}
+		m.msgPublishers, dropped = messages.Forward(m.msgPublishers, msg)
 		m.msgPublishersLock.Unlock()
+		metrics.Add("messages_dropped", int64(typeDropped + dropped))
 	}
 }
 
+//PeerEvents returns a handler which reports every peer joining and leaving the omni topic
+func (m *Manager) PeerEvents() (*pubsub.TopicEventHandler, error){
+	handler, err := m.topic.EventHandler()
+	if err != nil{
+		return nil, errors.Wrap(err, "creating omni topic event handler")
+	}
+	return handler, nil
+}
+
 //other parts of the node can call this to receive subscriber end of channel
 //over which messageForwarder will publish messages
 func (m *Manager) SubscribeToMessages() messages.Subscriber{
-	pub, sub := messages.NewSubscription()
+	pub, sub := messages.NewBufferedSubscription(messages.DefaultSubscriptionConfig())
 	m.msgPublishersLock.Lock()
 	defer m.msgPublishersLock.Unlock()
 	m.msgPublishers = append(m.msgPublishers, pub)
@@ -194,6 +268,30 @@ func (m *Manager) SubscribeToMessages() messages.Subscriber{
 	return sub
 }
 
+//Register adds the codec of a message type to the node: omni marshals and unmarshals the messages
+//of the type with it and hands the received ones to its Handler. registering a type twice panics
+func (m *Manager) Register(codec messages.Codec){
+	m.codecs.Register(codec)
+	pub, sub := messages.NewBufferedSubscription(messages.DefaultSubscriptionConfig())
+	m.msgPublishersLock.Lock()
+	m.typePublishers[codec.Type] = pub
+	m.msgPublishersLock.Unlock()
+
+	go m.dispatch(codec.Handler, sub)
+}
+
+//hand the received messages of a type to its handler, in the order they were received
+func (m *Manager) dispatch(handler func(messages.Message), sub messages.Subscriber){
+	for{
+		msg, err := sub.Next()
+		if err != nil{
+			m.logger.Error("failed receiving msg from messageForwarder", zap.Error(err))
+			continue
+		}
+		handler(msg)
+	}
+}
+
 //---------MESSAGING
 
 
@@ -0,0 +1,238 @@
+package omni
+
+import(
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"go.uber.org/zap"
+
+	"distry/messages"
+	genmsg "distry/proto_gen/messages"
+)
+
+//the codecs of a node which only exchanges rbc0 messages, it ignores what it receives
+func rbc0Codecs() *messages.Registry{
+	codecs := messages.NewRegistry()
+	codecs.Register(messages.Codec{
+		Type:				genmsg.Message_RBC0,
+		Marshal:			messages.MarshalRbc0,
+		Unmarshal:		messages.UnmarshalRbc0,
+		Handler:			func(messages.Message){},
+	})
+	return codecs
+}
+
+func TestFrames(t *testing.T){
+	buf := new(bytes.Buffer)
+	frames := [][]byte{[]byte("first"), {}, bytes.Repeat([]byte{7}, 300)}
+	for _, frame := range frames{
+		if err := writeFrame(buf, frame); err != nil{
+			t.Fatal(err)
+		}
+	}
+
+	reader := bufio.NewReader(buf)
+	for i, frame := range frames{
+		data, err := readFrame(reader)
+		if err != nil{
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, frame){
+			t.Fatalf("frame %d: read %d bytes, wrote %d", i, len(data), len(frame))
+		}
+	}
+	if _, err := readFrame(reader); err != io.EOF{
+		t.Fatalf("expected io.EOF after the last frame, got %v", err)
+	}
+}
+
+func TestFrameTruncatedAndOversized(t *testing.T){
+	buf := new(bytes.Buffer)
+	if err := writeFrame(buf, []byte("truncated")); err != nil{
+		t.Fatal(err)
+	}
+	truncated := bufio.NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	if _, err := readFrame(truncated); err != io.ErrUnexpectedEOF{
+		t.Fatalf("expected io.ErrUnexpectedEOF for a truncated frame, got %v", err)
+	}
+
+	if err := writeFrame(new(bytes.Buffer), make([]byte, maxFrameSize+1)); err == nil{
+		t.Fatal("wrote a frame over maxFrameSize")
+	}
+	//a peer announcing a huge frame is refused before anything is allocated
+	huge := bufio.NewReader(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0x0f}))
+	if _, err := readFrame(huge); err == nil{
+		t.Fatal("read a frame over maxFrameSize")
+	}
+}
+
+func TestOpenDropsUnknownTypes(t *testing.T){
+	m := &Manager{codecs: rbc0Codecs()}
+
+	for _, pb := range []*genmsg.Message{
+		{Type: genmsg.Message_ABA, Aba: &genmsg.Aba{SenderId: "x"}}, //no codec registered
+		{Type: genmsg.Message_Type(100)}, //type unknown to this version
+		{Type: genmsg.Message_RBC0}, //body missing
+	}{
+		data, err := pb.Marshal()
+		if err != nil{
+			t.Fatal(err)
+		}
+		if _, err := m.open(data); err == nil{
+			t.Fatalf("opened a message of type %s", pb.Type.String())
+		}
+	}
+}
+
+func TestDispatchByType(t *testing.T){
+	pub, sub := messages.NewSubscription()
+	m := &Manager{
+		logger:				zap.NewNop(),
+		codecs:				messages.NewRegistry(),
+		receivedPublisher:	pub,
+		typePublishers:	make(map[genmsg.Message_Type]messages.Publisher),
+	}
+	go m.messageForwarder(sub)
+
+	rbc0C := make(chan messages.Message, 2)
+	abaC := make(chan messages.Message, 2)
+	m.Register(messages.Codec{Type: genmsg.Message_RBC0, Marshal: messages.MarshalRbc0, Unmarshal: messages.UnmarshalRbc0, Handler: func(msg messages.Message){ rbc0C <- msg }})
+	m.Register(messages.Codec{Type: genmsg.Message_ABA, Marshal: messages.MarshalAba, Unmarshal: messages.UnmarshalAba, Handler: func(msg messages.Message){ abaC <- msg }})
+
+	for _, msg := range []messages.Message{messages.MsgAba{SessionID: "a"}, messages.MsgCbc{}, messages.Direct{Message: messages.MsgRbc0{ProtocolID: "r"}}}{
+		if err := pub.Publish(msg); err != nil{
+			t.Fatal(err)
+		}
+	}
+	for _, expected := range []struct{
+		c			chan messages.Message
+		check		func(messages.Message) bool
+	}{
+		{abaC, func(msg messages.Message) bool{ aba, ok := msg.(messages.MsgAba); return ok && aba.SessionID == "a" }},
+		{rbc0C, func(msg messages.Message) bool{ return messages.IsDirect(msg) && messages.Unwrap(msg).(messages.MsgRbc0).ProtocolID == "r" }},
+	}{
+		select{
+			case msg := <-expected.c:
+				if !expected.check(msg){
+					t.Fatalf("handler got %#v", msg)
+				}
+			case <-time.After(5*time.Second):
+				t.Fatal("message not handed to the handler of its type")
+		}
+	}
+	select{
+		case msg := <-rbc0C:
+			t.Fatalf("rbc0 handler got %#v", msg)
+		case msg := <-abaC:
+			t.Fatalf("aba handler got %#v", msg)
+		case <-time.After(100*time.Millisecond):
+	}
+}
+
+//a manager of direct streams only, its received messages are read off the returned subscriber
+func newDirectManager(t *testing.T, mn mocknet.Mocknet, i int) (*Manager, messages.Subscriber){
+	privKey, _, err := crypto.GenerateEd25519Key(nil)
+	if err != nil{
+		t.Fatal(err)
+	}
+	h, err := mn.AddPeer(privKey, ma.StringCast(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", 4000+i)))
+	if err != nil{
+		t.Fatal(err)
+	}
+	pub, sub := messages.NewSubscription()
+	m := &Manager{
+		logger:					zap.NewNop(),
+		NodeID:					h.ID(),
+		privKey:					privKey,
+		host:						h,
+		codecs:					rbc0Codecs(),
+		receivedPublisher:	pub,
+	}
+	h.SetStreamHandler(directProtocol, m.handleDirectStream)
+	return m, sub
+}
+
+func nextDirect(t *testing.T, sub messages.Subscriber) messages.Message{
+	received := make(chan messages.Message, 1)
+	go func(){
+		msg, err := sub.Next()
+		if err == nil{
+			received <- msg
+		}
+	}()
+	select{
+		case msg := <-received:
+			return msg
+		case <-time.After(5*time.Second):
+			t.Fatal("no direct message received")
+			return nil
+	}
+}
+
+func TestDirectStream(t *testing.T){
+	mn := mocknet.New(context.Background())
+	sender, _ := newDirectManager(t, mn, 0)
+	receiver, received := newDirectManager(t, mn, 1)
+	relay, _ := newDirectManager(t, mn, 2)
+	if err := mn.LinkAll(); err != nil{
+		t.Fatal(err)
+	}
+	if err := mn.ConnectAllButSelf(); err != nil{
+		t.Fatal(err)
+	}
+
+	//a direct message reaches the subscribers wrapped in messages.Direct
+	if err := sender.SendTo(receiver.ID(), &messages.MsgRbc0{ProtocolID: "direct", Payload: "x"}); err != nil{
+		t.Fatal(err)
+	}
+	msg := nextDirect(t, received)
+	rbc0Msg, ok := messages.Unwrap(msg).(messages.MsgRbc0)
+	if !messages.IsDirect(msg) || !ok{
+		t.Fatalf("received %#v", msg)
+	}
+	if rbc0Msg.ProtocolID != "direct" || rbc0Msg.SenderID != sender.ID().String(){
+		t.Fatalf("received %+v", rbc0Msg)
+	}
+
+	//a message signed by sender but relayed by another peer is dropped,
+	//the next frame of the stream, relay's own, still arrives
+	relayed, err := sender.seal(&messages.MsgRbc0{ProtocolID: "relayed"})
+	if err != nil{
+		t.Fatal(err)
+	}
+	own, err := relay.seal(&messages.MsgRbc0{ProtocolID: "own"})
+	if err != nil{
+		t.Fatal(err)
+	}
+	sendFrames(t, relay.host, receiver.host, relayed, own)
+	msg = nextDirect(t, received)
+	if rbc0Msg := messages.Unwrap(msg).(messages.MsgRbc0); rbc0Msg.ProtocolID != "own"{
+		t.Fatalf("received %+v after a relayed message", rbc0Msg)
+	}
+
+	if err := sender.SendTo(sender.ID(), &messages.MsgRbc0{}); err == nil{
+		t.Fatal("sent a direct message to self")
+	}
+}
+
+func sendFrames(t *testing.T, from, to host.Host, frames ...[]byte){
+	stream, err := from.NewStream(context.Background(), to.ID(), directProtocol)
+	if err != nil{
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	for _, frame := range frames{
+		if err := writeFrame(stream, frame); err != nil{
+			t.Fatal(err)
+		}
+	}
+}
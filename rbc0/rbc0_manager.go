@@ -1,181 +1,433 @@
 package rbc0
 
 import(
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"strconv"
-	"sync"
+	"strings"
+	"time"
 
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"distry/internal/retention"
+	"distry/membership"
 	"distry/messages"
-	"distry/omni"
+	genmsg "distry/proto_gen/messages"
 )
 
+//stages of a rbc round, they double as the types of rbc0 messages.
+//see proto/messages.proto
+const(
+	stageInit		uint32 = 1
+	stageEcho		uint32 = 2
+	stageReady		uint32 = 3
+	stageAccepted	uint32 = 4
+)
+
+//votes cast for one value of a rbc round
+type valueInfo struct{
+	payload string //content of the message carrying this value
+	//set of SENDER_IDs which sent an ECHO / READY for this value
+	echos		map[string]struct{}
+	readys	map[string]struct{}
+}
+
 //struct to keep info on a rbc round
 //round begins when some node INITs a message and ends with a message is ACCEPTED
 type roundInfo struct{
 	//stage this node is in in regards to a rbc round.
 	//'1':INIT, '2':ECHO, '3':READY, '4':ACCEPTED
 	localStage uint32;
-	peersPerStage map[uint32]int; //map [ STAGE -> number of all nodes in stage STAGE ]
-	payload string //content of the message of this round
-	//map [ SENDER_ID -> stage]
-	//store a map of which stage which peer is in
-	stageOfPeer	map[string]uint32
+	//membership epoch the round started in on this node. n, t and the peers are pinned to it,
+	//so peers joining or leaving mid-round don't change the quorums of the round.
+	epoch uint64
+	n, t int
+	peers map[string]struct{} //peers of the epoch, only their ECHOs and READYs count
+	initiator string //ID of the node which INITed the round, only its INIT counts
+	initDigest string //digest of the value received in the INIT, "" until it arrives
+	acceptedPayload string //value the round was accepted with
+	lastActivity time.Time //when the last message of the round was handled
+	acceptedAt time.Time
+
+	//map [ PAYLOAD_DIGEST -> valueInfo ]
+	//an equivocating initiator can send different values to different peers,
+	//so echos and readys are counted separately for every value.
+	values map[string]*valueInfo
+
+	//map [ SENDER_ID -> set of stages ]
+	//a correct peer sends at most one message of each type in a round,
+	//so only the first one is counted and the rest are ignored.
+	stagesOfPeer	map[string]map[uint32]bool
+}
+
+//Omni is the part of omni.Manager rbc0 relies on
+type Omni interface{
+	ID() peer.ID
+	OmniPublisher(messages.Message) error
+	Register(messages.Codec)
+}
 
-	
+//Membership is the part of membership.Manager rbc0 relies on
+type Membership interface{
+	Current() membership.Epoch
 }
 
+//events handled by the event loop, see eventLoop
+type broadcastEvent struct{
+	payload		string
+	protocolID	string //"" for the next protocolID of the node
+	resultC		chan<- broadcastResult
+}
+type broadcastResult struct{
+	protocolID	string //of the started round
+	err			error
+}
+type roundStatusEvent struct{
+	protocolID	string
+	statusC		chan<- *RoundStatus //nil is sent back if the round is unknown
+}
+type replayEvent struct{
+	fromSeq		uint64
+	replayC		chan<- []messages.Rbc0Delivery
+}
+
+//Manager runs bracha's reliable broadcast.
+//all protocol state below is owned by the event loop goroutine. Other goroutines
+//(API calls, the omni receiver) only ever hand events to it over channels.
 type Manager struct{
 	logger	*zap.Logger
-	omniManager *omni.Manager
+	cfg		Config
+	nodeID	string
+	omniManager Omni
+	membershipManager Membership
+
+	msgC				chan messages.MsgRbc0 //rbc0 messages received from the omni network
+	broadcastC		chan broadcastEvent
+	roundStatusC	chan roundStatusEvent
+	replayC			chan replayEvent
 
 	//map [ PROTOCOL_ID -> roundInfo ]
 	//for every round, keep info on it
 	roundInfoMap	map[string]*roundInfo
-
-	peersNum int //number of peers in the network
+	//PROTOCOL_IDs of the accepted rounds still in roundInfoMap, in the order they were accepted
+	acceptedOrder	[]string
+	//evicted rounds by LowWaterKey and counter, their messages are dropped
+	lowWater			*retention.LowWater
 
 	//as per bracha's article, each time msg INIT is broadcasted it needs a new protocolID.
-	//this is implemented using a counter, which increments after each INIT bcast
-	protocolCnt int
-
-	//this Manager sends ACCEPTED rbc messages via msgPublisher to msgPublishers
-	//other parts of the node are on the subscription end of the msgPublishers
-	msgPublisher		messages.Publisher
-	msgPublishers		[]messages.Publisher
-	msgPublishersLock	sync.RWMutex
+	//this is implemented using a counter, which increments after each INIT bcast.
+	//it is seeded from the clock so it keeps increasing across restarts of the node,
+	//otherwise peers would drop the new rounds as late.
+	protocolCnt uint64
+
+	//log of the last cfg.DeliveryLogSize deliveries, so subscribers can replay them
+	deliveries			[]messages.Rbc0Delivery
+	deliverySeq			uint64 //Seq of the last delivery
+
+	//this Manager sends ACCEPTED rbc messages via msgPublisher to the subscribers
+	msgPublisher	messages.Publisher
+	subscribers		messages.Fanout
 }
 
-func NewManager(logger *zap.Logger, peersNum int, omniManager *omni.Manager) *Manager{
+func NewManager(logger *zap.Logger, cfg Config, membershipManager Membership, omniManager Omni) *Manager{
 	if logger == nil{
 		logger = zap.NewNop()
 	}
+	cfg = cfg.WithDefaults()
 
 	pub, sub := messages.NewSubscription()
 
 	m := &Manager{
 		logger:			logger,
+		cfg:				cfg,
+		nodeID:			omniManager.ID().String(),
 		omniManager:	omniManager,
+		membershipManager:	membershipManager,
+		msgC:				make(chan messages.MsgRbc0),
+		broadcastC:		make(chan broadcastEvent),
+		roundStatusC:	make(chan roundStatusEvent),
+		replayC:			make(chan replayEvent),
 		roundInfoMap:	make(map[string]*roundInfo),
-		peersNum:		peersNum,
+		acceptedOrder:	make([]string, 0),
+		lowWater:		retention.NewLowWater(cfg.EvictedWindow),
+		protocolCnt:	uint64(time.Now().UnixNano()),
 		msgPublisher:	pub,
-		msgPublishers:	make([]messages.Publisher, 0),
 	}
 
-	go m.messageForwarder(sub)
-	go m.omniMsgReceiver()
+	go m.subscribers.Run(sub, func(dropped uint64){ metrics.Add("messages_dropped", int64(dropped)) })
+	go m.eventLoop()
+	m.omniManager.Register(messages.Codec{
+		Type:				genmsg.Message_RBC0,
+		Marshal:			messages.MarshalRbc0,
+		Unmarshal:		messages.UnmarshalRbc0,
+		Handler:			m.handleOmniMsg,
+	})
 	return m
 }
 
-func (m *Manager) omniMsgReceiver(){
-	sub := m.omniManager.SubscribeToMessages()
+//the only goroutine which reads or changes protocol state.
+//it takes local broadcast requests, network messages and timer ticks one at a time,
+//so no state needs locking.
+func (m *Manager) eventLoop(){
+	gcTicker := time.NewTicker(m.cfg.GCInterval)
+	defer gcTicker.Stop()
 
 	for{
-		in, err := sub.Next()
-		if err != nil{
-			m.logger.Error("failed receiving msg from omniManager", zap.Error(err))
-			continue
+		select{
+			case msg := <-m.msgC:
+				m.handleMsg(msg)
+			case ev := <-m.broadcastC:
+				protocolID, err := m.startRound(ev.protocolID, ev.payload)
+				ev.resultC <- broadcastResult{protocolID: protocolID, err: err}
+			case ev := <-m.roundStatusC:
+				ev.statusC <- m.roundStatus(ev.protocolID)
+			case ev := <-m.replayC:
+				ev.replayC <- m.replay(ev.fromSeq)
+			case now := <-gcTicker.C:
+				m.gc(now)
 		}
+	}
+}
 
-		var msg messages.MsgRbc0
-		switch in.(type){
-			case messages.MsgRbc0:
-				//m.logger.Debug("received rbc0 msg")
-				msg = in.(messages.MsgRbc0)
-			default:
-				m.logger.Debug("rbc0 discarding foreign msg type")
-				continue
-		}
+//Handler of the codec registered with omni, pass on the received messages to the event loop
+func (m *Manager) handleOmniMsg(in messages.Message){
+	in = messages.Unwrap(in)
+	var msg messages.MsgRbc0
+	switch in.(type){
+		case messages.MsgRbc0:
+			//m.logger.Debug("received rbc0 msg")
+			msg = in.(messages.MsgRbc0)
+		default:
+			m.logger.Debug("rbc0 discarding foreign msg type")
+			return
+	}
 
-		thisRoundInfo, exists := m.roundInfoMap[msg.ProtocolID]
-		if exists && thisRoundInfo.localStage == 4 { //this round was already accepted
-			continue
+	m.msgC <- msg
+}
+
+//count the vote carried by msg towards the value it carries, then check whether the round
+//can move forward for that value
+func (m *Manager) handleMsg(msg messages.MsgRbc0){
+	thisRoundInfo, exists := m.roundInfoMap[msg.ProtocolID]
+	if !exists{ //NEW MESSAGE ROUND (NEW PROTOCOL_ID)
+		if m.isLate(msg.ProtocolID){ //round was evicted (or can't be tracked)
+			metrics.Add("late_msgs_dropped", 1)
+			return
 		}
-		if !exists{ //NEW MESSAGE ROUND (NEW PROTOCOL_ID)
-			m.logger.Debug("new message round")
-			m.instantiateRoundInfo(msg.ProtocolID, msg.Payload)
-			m.roundInfoMap[msg.ProtocolID].peersPerStage[msg.Type]++
-		} else { //UPDATE ROUND
-			senderNodeStage, exists := thisRoundInfo.stageOfPeer[msg.SenderID]
-			if !exists || senderNodeStage < msg.Type{
-				m.roundInfoMap[msg.ProtocolID].stageOfPeer[msg.SenderID] = msg.Type
-				m.roundInfoMap[msg.ProtocolID].peersPerStage[msg.Type]++
-			} else {
-				//sender resent a message from the same stage of a round. UNDEFINED BEHAVIOUR
-				m.logger.Warn("some weird sender stage fuckery")
-				continue
-			}
+		if IsOutsider(m.membershipManager.Current(), msg.ProtocolID){
+			metrics.Add("outsider_msgs_dropped", 1)
+			return
 		}
+		thisRoundInfo = m.instantiateRoundInfo(msg.ProtocolID)
+	}
+	if thisRoundInfo.localStage == stageAccepted{ //this round was already accepted
+		return
+	}
+	thisRoundInfo.lastActivity = time.Now()
+
+	if msg.Type < stageInit || msg.Type > stageReady{
+		m.logger.Warn("rbc0 discarding msg of unknown type", zap.Uint32("type", msg.Type))
+		return
+	}
+	if msg.Type == stageInit && msg.SenderID != thisRoundInfo.initiator{
+		m.logger.Warn("rbc0 discarding INIT not sent by the initiator of the round",
+			zap.String("protocolID", msg.ProtocolID),
+			zap.String("senderID", msg.SenderID),
+		)
+		return
+	}
+	if _, isPeer := thisRoundInfo.peers[msg.SenderID]; !isPeer{
+		m.logger.Warn("rbc0 discarding msg of a node which is no peer of the round's epoch",
+			zap.String("protocolID", msg.ProtocolID),
+			zap.String("senderID", msg.SenderID),
+		)
+		return
+	}
 
-		m.checkRound(msg.ProtocolID)
+	senderStages, exists := thisRoundInfo.stagesOfPeer[msg.SenderID]
+	if !exists{
+		senderStages = make(map[uint32]bool)
+		thisRoundInfo.stagesOfPeer[msg.SenderID] = senderStages
+	}
+	if senderStages[msg.Type]{
+		//sender resent a message of the same type in this round, possibly with another value.
+		m.logger.Warn("rbc0 ignoring repeated msg of the same type from sender",
+			zap.String("protocolID", msg.ProtocolID),
+			zap.String("senderID", msg.SenderID),
+			zap.Uint32("type", msg.Type),
+		)
+		return
+	}
+	senderStages[msg.Type] = true
+
+	dgst := digest(msg.Payload)
+	value := thisRoundInfo.value(dgst, msg.Payload)
+	switch msg.Type{
+		case stageInit:
+			thisRoundInfo.initDigest = dgst
+		case stageEcho:
+			value.echos[msg.SenderID] = struct{}{}
+		case stageReady:
+			value.readys[msg.SenderID] = struct{}{}
 	}
+
+	m.checkRound(msg.ProtocolID, dgst)
 }
 
-func (m *Manager) instantiateRoundInfo(protocolID, payload string){
+func (m *Manager) instantiateRoundInfo(protocolID string) *roundInfo{
+	epoch := m.membershipManager.Current()
+
 	var ri roundInfo
 	ri.localStage = 0
-	ri.peersPerStage = map[uint32]int{1:0, 2:0, 3:0}
-	ri.payload = payload
-	ri.stageOfPeer = make(map[string]uint32)
+	ri.epoch = epoch.Number
+	ri.n = epoch.N
+	ri.t = MaxFaulty(epoch.N)
+	ri.peers = make(map[string]struct{}, len(epoch.Peers))
+	for _, p := range epoch.Peers{
+		ri.peers[p.String()] = struct{}{}
+	}
+	ri.initiator, _, _, _ = ParseProtocolID(protocolID)
+	ri.lastActivity = time.Now()
+	ri.values = make(map[string]*valueInfo)
+	ri.stagesOfPeer = make(map[string]map[uint32]bool)
 	m.roundInfoMap[protocolID] = &ri
+	m.updateMetrics()
+
+	m.logger.Debug("new message round",
+		zap.String("protocolID", protocolID),
+		zap.Uint64("epoch", ri.epoch),
+		zap.Int("n", ri.n),
+		zap.Int("t", ri.t),
+	)
+	return &ri
+}
+
+//return the votes of the value with the digest dgst, create them if this is its first vote
+func (ri *roundInfo) value(dgst, payload string) *valueInfo{
+	value, exists := ri.values[dgst]
+	if !exists{
+		value = &valueInfo{
+			payload:	payload,
+			echos:	make(map[string]struct{}),
+			readys:	make(map[string]struct{}),
+		}
+		ri.values[dgst] = value
+	}
+	return value
+}
+
+//values are compared and counted by the hash of their payload
+func digest(payload string) string{
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])
+}
+
+//MaxFaulty returns the max number of faulty proccesses (matching bracha's naming scheme) among n processes.
+//bracha's rbc tolerates 0 <= t < n/3
+func MaxFaulty(n int) int{
+	if n < 1{
+		return 0
+	}
+	return (n-1)/3
 }
 
 
 //follow the protocol description
 //after a message for a round was received, check if
-//enough inits/echos/readys have been received to move local node to next stage
+//enough inits/echos/readys have been received for the value with digest dgst
+//to move local node to next stage
 //if yes, broadcast next stage message
 //after local stage is ACCEPT, send signal to other parts of the node and cleanup maps
-func (m *Manager) checkRound(protocolID string){
-	localStage := m.roundInfoMap[protocolID].localStage
-	inits := m.roundInfoMap[protocolID].peersPerStage[1]
-	echos := m.roundInfoMap[protocolID].peersPerStage[2]
-	readys := m.roundInfoMap[protocolID].peersPerStage[3]
-	if localStage == 4{ //received messages after already accepted, just ignore
-		return
+func (m *Manager) checkRound(protocolID, dgst string){
+	ri := m.roundInfoMap[protocolID]
+	value := ri.values[dgst]
+
+	n := ri.n //number of all nodes (matching bracha's naming scheme)
+	t := ri.t
+
+	//this node's own votes are counted as well, so moving to a stage can allow moving
+	//further. Keep checking until the round is stuck.
+	for ri.localStage != stageAccepted{
+		inited := ri.initDigest == dgst
+		echos := len(value.echos)
+		readys := len(value.readys)
+
+		if readys >= 2*t+1 && ri.localStage == stageReady{ // enter ACCEPT stage
+			m.accept(protocolID, value.payload)
+		} else if ri.localStage < stageReady && (echos >= n-t || readys >= t+1){ // enter READY stage
+			if ri.localStage < stageEcho{ //a READY is never sent before an ECHO
+				m.vote(protocolID, stageEcho, value)
+			}
+			m.vote(protocolID, stageReady, value)
+		} else if ri.localStage < stageEcho && inited{ // enter ECHO stage
+			m.vote(protocolID, stageEcho, value)
+		} else{
+			return
+		}
 	}
+}
 
-	n := m.peersNum //number of all nodes (matching bracha's naming scheme)
-	t := n/3 -1 //max number of faulty proccesses (matching bracha's naming scheme)
-	if t < 0{ //so algo works even when peersNum <= 3
-		t = 0
+//move the local node to stage for a value, broadcast it and count it as this node's vote
+func (m *Manager) vote(protocolID string, stage uint32, value *valueInfo){
+	ri := m.roundInfoMap[protocolID]
+	ri.localStage = stage
+	m.broadcast(protocolID, stage, value.payload)
+
+	switch stage{
+		case stageEcho:
+			value.echos[m.nodeID] = struct{}{}
+		case stageReady:
+			value.readys[m.nodeID] = struct{}{}
 	}
+}
 
-	if readys >= 1 + 2*t{ // enter ACCEPT stage
-		if localStage != 3{ //if READY was not yet sent before, send it
-			m.broadcast(protocolID, 3) //broadcast ready
-		}
-		m.roundInfoMap[protocolID].localStage = 4
-		m.logger.Info("round %s ACCEPTADO:",
-			zap.String("protocolID", protocolID),
-			zap.String("pld", m.roundInfoMap[protocolID].payload),
-		)
+func (m *Manager) accept(protocolID, payload string){
+	ri := m.roundInfoMap[protocolID]
+	ri.localStage = stageAccepted
+	ri.acceptedPayload = payload
+	ri.acceptedAt = time.Now()
+	m.acceptedOrder = append(m.acceptedOrder, protocolID)
+	m.updateMetrics()
+	m.logger.Info("round %s ACCEPTADO:",
+		zap.String("protocolID", protocolID),
+		zap.String("pld", payload),
+		zap.Uint64("epoch", ri.epoch),
+		zap.Int("n", ri.n),
+		zap.Int("t", ri.t),
+	)
+
+	//log the delivery and send it out to other the messageForwarder
+	m.deliverySeq++
+	msg := messages.Rbc0Delivery{
+		Seq:				m.deliverySeq,
+		SenderID:		ri.initiator,
+		ProtocolID:		protocolID,
+		Payload:			payload,
+		AcceptedAt:		ri.acceptedAt,
+	}
+	m.deliveries = append(m.deliveries, msg)
+	if len(m.deliveries) > m.cfg.DeliveryLogSize{
+		m.deliveries = m.deliveries[len(m.deliveries)-m.cfg.DeliveryLogSize:]
+	}
 
-		//send out accepted message to other the messageForwarder
-		var msg messages.MsgRbc0
-		msg.Payload = m.roundInfoMap[protocolID].payload
-		if err := m.msgPublisher.Publish(msg); err != nil{
-			m.logger.Error("failed passing rbc message to messageForwarder")
-		}
-		//cleanup round resources
-		m.roundInfoMap[protocolID].payload = ""
-		m.roundInfoMap[protocolID].peersPerStage = nil
-		m.roundInfoMap[protocolID].stageOfPeer = nil
-	} else if echos >= (n+t)/2 || readys >= t+1{ // enter READY stage
-		m.roundInfoMap[protocolID].localStage = 3
-		m.broadcast(protocolID, 3) //broadcast ready
-	} else if inits == 1 || echos >= (n+t)/2 || readys >= t+1{ // enter ECHO stage
-		m.roundInfoMap[protocolID].localStage = 2
-		m.broadcast(protocolID, 2) //broadcast echo
+	if err := m.msgPublisher.Publish(msg); err != nil{
+		m.logger.Error("failed passing rbc message to the subscribers")
 	}
+	//cleanup round resources
+	m.roundInfoMap[protocolID].values = nil
+	m.roundInfoMap[protocolID].stagesOfPeer = nil
+	m.roundInfoMap[protocolID].peers = nil
 }
 
 //stage: '1':INIT, '2':ECHO, '3':READY
-func (m *Manager) broadcast(protocolID string, stage uint32){
+func (m *Manager) broadcast(protocolID string, stage uint32, payload string){
 	msg := messages.MsgRbc0{
 		ProtocolID:		protocolID,
-		Type:				stage, //INIT, see proto/messages.proto
-		Payload:			m.roundInfoMap[protocolID].payload,
+		Type:				stage, //see proto/messages.proto
+		Payload:			payload,
 	}
 	if err := m.omniManager.OmniPublisher(&msg); err != nil{
 		m.logger.Error("sending rbc0 msg in round FAILED", zap.String("protocolID", protocolID))
@@ -183,55 +435,179 @@ func (m *Manager) broadcast(protocolID string, stage uint32){
 }
 
 
-//forward messages received from omni network to other parts of the node (like rbc0)
-func (m *Manager) messageForwarder(sub messages.Subscriber){
-	for{
-		msg, err := sub.Next()
-		if err != nil{
-			m.logger.Error("failer receiving msg from rbc receiver", zap.Error(err))
-			continue
-		}
+//other parts of the node can call this to receive the Rbc0Deliveries of this node
+func (m *Manager) SubscribeToMessages() messages.Subscriber{
+	return m.Subscribe(messages.DefaultSubscriptionConfig())
+}
 
-		m.msgPublishersLock.Lock()
-		for _, pub := range m.msgPublishers{
-			if pub.Closed(){
-				continue
-			} else if err := pub.Publish(msg); err != nil{
-				m.logger.Error("failed forwarding messsage", zap.Error(err))
-			}
+//Subscribe is SubscribeToMessages with a subscription buffer and overflow policy of choice
+func (m *Manager) Subscribe(cfg messages.SubscriptionConfig) messages.Subscriber{
+	return m.subscribers.Subscribe(cfg)
+}
+
+//SubscribeDeliveries returns the logged deliveries with Seq >= fromSeq (none if fromSeq is 0),
+//along with a subscription to every later delivery.
+//the subscription is made before the log is read, so no delivery is missed in between,
+//but a delivery can show up in both. Skip those by their Seq.
+//a subscriber which falls more than DeliveryBuffer deliveries behind loses the oldest ones
+//instead of stalling the node. The gap shows in Seq, so it can subscribe again from there.
+func (m *Manager) SubscribeDeliveries(fromSeq uint64) ([]messages.Rbc0Delivery, messages.Subscriber){
+	sub := m.Subscribe(messages.SubscriptionConfig{Buffer: m.cfg.DeliveryBuffer, Policy: messages.DropOldest})
+	if fromSeq == 0{
+		return nil, sub
+	}
+
+	replayC := make(chan []messages.Rbc0Delivery, 1)
+	m.replayC <- replayEvent{fromSeq: fromSeq, replayC: replayC}
+	return <-replayC, sub
+}
+
+//return the logged deliveries with Seq >= fromSeq
+func (m *Manager) replay(fromSeq uint64) []messages.Rbc0Delivery{
+	replay := make([]messages.Rbc0Delivery, 0)
+	for _, delivery := range m.deliveries{
+		if delivery.Seq >= fromSeq{
+			replay = append(replay, delivery)
 		}
-		m.msgPublishersLock.Unlock()
 	}
+	return replay
 }
 
+//RoundStatus describes how far a rbc round got on this node
+type RoundStatus struct{
+	ProtocolID	string
+	//'0':NOTHING SENT YET, '2':ECHO, '3':READY, '4':ACCEPTED
+	Stage			uint32
+	Accepted		bool
+	Payload		string //value the round was accepted with, "" until it is
+	Epoch			uint64 //membership epoch the round started in
+	N, T			int
+}
 
-//other parts of the node can call this to receive subscriber end of channel
-//over which messageForwarder will publish messages
-func (m *Manager) SubscribeToMessages() messages.Subscriber{
-	pub, sub := messages.NewSubscription()
-	m.msgPublishersLock.Lock()
-	defer m.msgPublishersLock.Unlock()
-	m.msgPublishers = append(m.msgPublishers, pub)
+//NotAcceptedError is returned by Broadcast when its context ends before the round is
+//accepted. The round itself keeps going and can be polled with RoundStatus.
+type NotAcceptedError struct{
+	ProtocolID	string
+	Err			error //why the context ended
+}
+
+func (e *NotAcceptedError) Error() string{
+	return "rbc0 round " + e.ProtocolID + " not accepted yet: " + e.Err.Error()
+}
 
-	return sub
+func (e *NotAcceptedError) Cause() error{
+	return e.Err
 }
 
-func (m *Manager) Broadcast(nodeID, payload string) (bool, error){
-	protocolID := nodeID + "_" + strconv.Itoa(m.protocolCnt)
-	m.instantiateRoundInfo(protocolID, payload)
+//RoundStatus returns the status of the round protocolID,
+//false if this node knows nothing of it (or no longer does, see Config)
+func (m *Manager) RoundStatus(protocolID string) (RoundStatus, bool){
+	statusC := make(chan *RoundStatus, 1)
+	m.roundStatusC <- roundStatusEvent{protocolID: protocolID, statusC: statusC}
+	status := <-statusC
+	if status == nil{
+		return RoundStatus{}, false
+	}
+	return *status, true
+}
+
+func (m *Manager) roundStatus(protocolID string) *RoundStatus{
+	ri, exists := m.roundInfoMap[protocolID]
+	if !exists{
+		return nil
+	}
+
+	return &RoundStatus{
+		ProtocolID:	protocolID,
+		Stage:		ri.localStage,
+		Accepted:	ri.localStage == stageAccepted,
+		Payload:		ri.acceptedPayload,
+		Epoch:		ri.epoch,
+		N:				ri.n,
+		T:				ri.t,
+	}
+}
+
+//Broadcast INITs a new round with payload and returns its delivery once it is accepted.
+//if ctx ends first, a *NotAcceptedError carrying the protocolID of the round is returned.
+func (m *Manager) Broadcast(ctx context.Context, payload string) (messages.Rbc0Delivery, error){
+	return m.broadcastRound(ctx, "", payload)
+}
+
+//BroadcastInstance is Broadcast for a round whose protocolID other nodes can tell in advance:
+//NODE_ID + "_" + stream + "-" + counter. It lets protocols built on rbc0 run one round per
+//(initiator, instance), so a faulty initiator can't get two payloads accepted for an instance.
+//the stream must not contain "_" or "-", and an instance can be broadcast only once.
+func (m *Manager) BroadcastInstance(ctx context.Context, stream string, counter uint64, payload string) (messages.Rbc0Delivery, error){
+	if stream == "" || strings.ContainsAny(stream, "_-"){
+		return messages.Rbc0Delivery{}, errors.Errorf("invalid rbc0 stream %q", stream)
+	}
+	return m.broadcastRound(ctx, InstanceID(m.nodeID, stream, counter), payload)
+}
+
+//InstanceID returns the protocolID of the round initiator broadcasts for instance counter
+//of stream, see BroadcastInstance
+func InstanceID(initiator, stream string, counter uint64) string{
+	return initiator + "_" + stream + "-" + strconv.FormatUint(counter, 10)
+}
+
+func (m *Manager) broadcastRound(ctx context.Context, protocolID, payload string) (messages.Rbc0Delivery, error){
+	//subscribe before the round starts, so its delivery can't be missed
 	sub := m.SubscribeToMessages()
+	defer sub.Close()
 
-	m.broadcast(protocolID, 1)
-	m.logger.Debug("sending rbc0 INIT: DONE", zap.String("protocolID", protocolID))
-	m.protocolCnt++
+	resultC := make(chan broadcastResult, 1)
+	m.broadcastC <- broadcastEvent{payload: payload, protocolID: protocolID, resultC: resultC}
+	result := <-resultC
+	if result.err != nil{
+		return messages.Rbc0Delivery{}, result.err
+	}
+	protocolID = result.protocolID
 
 	//wait for message to be ACCEPTADO
-	//TODO timeout ?
-	_, err := sub.Next()
-	sub.Close()
-	if err != nil {
-		m.logger.Error("failed receiving message initiated by BROADCAST", zap.Error(err))
-		return false, err
-	}
-	return true, nil
+	//other rounds (started by other API calls or other nodes) are accepted meanwhile,
+	//skip their deliveries.
+	msg, err := messages.WaitFor(ctx, sub, func(msg messages.Message) bool{
+		delivery, ok := msg.(messages.Rbc0Delivery)
+		return ok && delivery.ProtocolID == protocolID
+	})
+	switch{
+		case err == nil:
+			return msg.(messages.Rbc0Delivery), nil
+		case err == ctx.Err():
+			m.logger.Debug("rbc0 round not accepted before deadline", zap.String("protocolID", protocolID))
+			return messages.Rbc0Delivery{}, &NotAcceptedError{ProtocolID: protocolID, Err: err}
+		default:
+			m.logger.Error("failed receiving message initiated by BROADCAST", zap.Error(err))
+			return messages.Rbc0Delivery{}, err
+	}
+}
+
+//INIT a new round with payload, return its protocolID.
+//if protocolID is "", the next protocolID of the node is used.
+func (m *Manager) startRound(protocolID, payload string) (string, error){
+	if protocolID == ""{
+		protocolID = m.nodeID + "_" + strconv.FormatUint(m.protocolCnt, 10)
+		m.protocolCnt++
+	} else if ri, exists := m.roundInfoMap[protocolID]; exists && ri.stagesOfPeer != nil && ri.stagesOfPeer[m.nodeID][stageInit]{
+		return "", errors.Errorf("rbc0 round %s was already INITed", protocolID)
+	} else if !exists && m.isLate(protocolID){
+		return "", errors.Errorf("rbc0 round %s was already evicted", protocolID)
+	} else if exists && ri.localStage == stageAccepted{
+		return "", errors.Errorf("rbc0 round %s was already accepted", protocolID)
+	}
+
+	m.broadcast(protocolID, stageInit, payload)
+	m.logger.Debug("sending rbc0 INIT: DONE", zap.String("protocolID", protocolID))
+
+	//own messages don't come back from the omni network, so deliver the INIT locally
+	//for this node to ECHO it like every other node will
+	m.handleMsg(messages.MsgRbc0{
+		SenderID:		m.nodeID,
+		ProtocolID:		protocolID,
+		Type:				stageInit,
+		Payload:			payload,
+	})
+
+	return protocolID, nil
 }
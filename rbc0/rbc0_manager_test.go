@@ -0,0 +1,570 @@
+package rbc0
+
+import(
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"distry/internal/retention"
+	"distry/membership"
+	"distry/messages"
+)
+
+//fakeNetwork stands in for the omni network, every published message reaches every other node
+type fakeNetwork struct{
+	nodes []*fakeOmni
+}
+
+//fakeOmni stands in for omni.Manager of a single node
+type fakeOmni struct{
+	id			peer.ID
+	network	*fakeNetwork
+
+	//messages wait here until the receiver picks them up, so publishing never blocks
+	//(like publishing to the pubsub topic)
+	inbox			[]messages.Message
+	inboxLock	sync.Mutex
+	inboxC		chan struct{}
+
+	handler		func(messages.Message) //of the rbc0 codec, the only one registered
+	handlerLock	sync.Mutex
+}
+
+func (o *fakeOmni) ID() peer.ID{
+	return o.id
+}
+
+func (o *fakeOmni) OmniPublisher(msg messages.Message) error{
+	rbc0Msg := *msg.(*messages.MsgRbc0)
+	rbc0Msg.SenderID = o.id.String()
+	for _, node := range o.network.nodes{
+		if node != o{ //own messages don't come back from the omni network
+			node.enqueue(rbc0Msg)
+		}
+	}
+	return nil
+}
+
+func (o *fakeOmni) Register(codec messages.Codec){
+	o.handlerLock.Lock()
+	defer o.handlerLock.Unlock()
+	o.handler = codec.Handler
+}
+
+func (o *fakeOmni) enqueue(msg messages.Message){
+	o.inboxLock.Lock()
+	o.inbox = append(o.inbox, msg)
+	o.inboxLock.Unlock()
+
+	select{
+		case o.inboxC <- struct{}{}:
+		default:
+	}
+}
+
+//hand queued messages to the handler
+func (o *fakeOmni) receiver(){
+	for range o.inboxC{
+		for{
+			o.inboxLock.Lock()
+			if len(o.inbox) == 0{
+				o.inboxLock.Unlock()
+				break
+			}
+			msg := o.inbox[0]
+			o.inbox = o.inbox[1:]
+			o.inboxLock.Unlock()
+
+			o.handlerLock.Lock()
+			handler := o.handler
+			o.handlerLock.Unlock()
+			if handler != nil{
+				handler(msg)
+			}
+		}
+	}
+}
+
+type fakeMembership struct{
+	epoch membership.Epoch
+}
+
+func (f fakeMembership) Current() membership.Epoch{
+	return f.epoch
+}
+
+//create n rbc0 Managers connected through a fakeNetwork
+func setupManagers(t *testing.T, n int) []*Manager{
+	t.Helper()
+
+	network := &fakeNetwork{}
+	epoch := membership.Epoch{Number: 1, N: n}
+	for i := 0; i < n; i++{
+		node := &fakeOmni{
+			id:		peer.ID(fmt.Sprintf("node%d", i)),
+			network:	network,
+			inboxC:	make(chan struct{}, 1),
+		}
+		network.nodes = append(network.nodes, node)
+		epoch.Peers = append(epoch.Peers, node.id)
+		go node.receiver()
+	}
+
+	managers := make([]*Manager, n)
+	for i, node := range network.nodes{
+		managers[i] = NewManager(nil, DefaultConfig(), fakeMembership{epoch}, node)
+	}
+	return managers
+}
+
+func TestConcurrentBroadcasts(t *testing.T){
+	const nodesNum = 4
+	const broadcastsPerNode = 25
+
+	managers := setupManagers(t, nodesNum)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	type result struct{
+		payload	string
+		delivery	messages.Rbc0Delivery
+		err		error
+	}
+	results := make(chan result, nodesNum*broadcastsPerNode)
+
+	wg := new(sync.WaitGroup)
+	for i, m := range managers{
+		for j := 0; j < broadcastsPerNode; j++{
+			wg.Add(1)
+			go func(m *Manager, payload string){
+				defer wg.Done()
+				delivery, err := m.Broadcast(ctx, payload)
+				results <- result{payload: payload, delivery: delivery, err: err}
+			}(m, fmt.Sprintf("payload %d-%d", i, j))
+		}
+	}
+	wg.Wait()
+	close(results)
+
+	protocolIDs := make(map[string]string) //PROTOCOL_ID -> payload
+	for r := range results{
+		if r.err != nil{
+			t.Fatalf("broadcast of %q failed: %v", r.payload, r.err)
+		}
+		//every caller must be unblocked by its own round
+		if r.delivery.Payload != r.payload{
+			t.Fatalf("broadcast of %q returned delivery of %q", r.payload, r.delivery.Payload)
+		}
+		if _, exists := protocolIDs[r.delivery.ProtocolID]; exists{
+			t.Fatalf("protocolID %s used by more than one round", r.delivery.ProtocolID)
+		}
+		protocolIDs[r.delivery.ProtocolID] = r.payload
+	}
+
+	//every node accepts every round, with the same value
+	for protocolID, payload := range protocolIDs{
+		for i, m := range managers{
+			var status RoundStatus
+			for{
+				var exists bool
+				status, exists = m.RoundStatus(protocolID)
+				if exists && status.Accepted{
+					break
+				}
+				select{
+					case <-ctx.Done():
+						t.Fatalf("node %d did not accept round %s", i, protocolID)
+					case <-time.After(10*time.Millisecond):
+				}
+			}
+			if status.Payload != payload{
+				t.Fatalf("node %d accepted %q in round %s, want %q", i, status.Payload, protocolID, payload)
+			}
+			if status.N != nodesNum || status.T != 1{
+				t.Fatalf("round %s pinned n=%d t=%d, want n=%d t=1", protocolID, status.N, status.T, nodesNum)
+			}
+		}
+	}
+}
+
+func TestBroadcastNotAccepted(t *testing.T){
+	//the other 3 nodes of the epoch never answer, so the round can't get its quorums
+	network := &fakeNetwork{}
+	node := &fakeOmni{id: peer.ID("node0"), network: network, inboxC: make(chan struct{}, 1)}
+	network.nodes = append(network.nodes, node)
+	go node.receiver()
+	m := NewManager(nil, DefaultConfig(), fakeMembership{membership.Epoch{Number: 1, N: 4, Peers: []peer.ID{"node0", "node1", "node2", "node3"}}}, node)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := m.Broadcast(ctx, "lonely")
+	notAccepted, ok := err.(*NotAcceptedError)
+	if !ok{
+		t.Fatalf("got error %v, want *NotAcceptedError", err)
+	}
+
+	status, exists := m.RoundStatus(notAccepted.ProtocolID)
+	if !exists || status.Accepted{
+		t.Fatalf("round %s: exists=%v accepted=%v, want a running round", notAccepted.ProtocolID, exists, status.Accepted)
+	}
+}
+
+//ID of node i of the lone Manager's epoch, as its messages carry it
+func nodeID(i int) string{
+	return peer.ID(fmt.Sprintf("node%d", i)).String()
+}
+
+//protocolID of the round cnt of node1
+func roundID(cnt uint64) string{
+	return nodeID(1) + "_" + strconv.FormatUint(cnt, 10)
+}
+
+//create a Manager of node0 in an epoch of node0 to node3.
+//the other nodes don't run, their messages are handed to it with deliver.
+func setupLoneManager(t *testing.T, cfg Config) (m *Manager, deliver func(msg messages.MsgRbc0)){
+	t.Helper()
+
+	network := &fakeNetwork{}
+	node := &fakeOmni{id: peer.ID("node0"), network: network, inboxC: make(chan struct{}, 1)}
+	network.nodes = append(network.nodes, node)
+	go node.receiver()
+	epoch := membership.Epoch{Number: 1, N: 4, Peers: []peer.ID{"node0", "node1", "node2", "node3"}}
+	m = NewManager(nil, cfg, fakeMembership{epoch}, node)
+	return m, func(msg messages.MsgRbc0){ node.enqueue(msg) }
+}
+
+func TestZeroConfig(t *testing.T){
+	if cfg := (Config{}).WithDefaults(); cfg != DefaultConfig(){
+		t.Fatalf("zero config got %+v, want the defaults", cfg)
+	}
+}
+
+//rounds stall quickly and few evicted rounds are remembered one by one
+func stallingConfig() Config{
+	cfg := DefaultConfig()
+	cfg.StalledTimeout = 100*time.Millisecond
+	cfg.GCInterval = 5*time.Millisecond
+	cfg.EvictedWindow = 2
+	return cfg
+}
+
+//wait until the round exists or not
+func waitRound(t *testing.T, m *Manager, protocolID string, exists bool){
+	t.Helper()
+	deadline := time.Now().Add(5*time.Second)
+	for{
+		if _, ok := m.RoundStatus(protocolID); ok == exists{
+			return
+		}
+		if time.Now().After(deadline){
+			t.Fatalf("round %s: expected exists=%v", protocolID, exists)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLateMessages(t *testing.T){
+	m, deliver := setupLoneManager(t, stallingConfig())
+	initOf := func(protocolID string) messages.MsgRbc0{
+		return messages.MsgRbc0{SenderID: nodeID(1), ProtocolID: protocolID, Type: stageInit, Payload: "x"}
+	}
+
+	//an INITed round is remembered once it stalls and is evicted
+	deliver(initOf(roundID(10)))
+	waitRound(t, m, roundID(10), true)
+	waitRound(t, m, roundID(10), false)
+	deliver(initOf(roundID(10)))
+	//a lower round not seen yet still runs, messages are handled in order
+	deliver(initOf(roundID(9)))
+	waitRound(t, m, roundID(9), true)
+	if _, exists := m.RoundStatus(roundID(10)); exists{
+		t.Fatal("the late INIT of an evicted round started it anew")
+	}
+
+	//once more than the window are evicted, the rounds below them are late
+	deliver(initOf(roundID(11)))
+	waitRound(t, m, roundID(11), true)
+	waitRound(t, m, roundID(9), false)
+	waitRound(t, m, roundID(11), false)
+	deliver(initOf(roundID(8)))
+	deliver(initOf(roundID(12)))
+	waitRound(t, m, roundID(12), true)
+	if _, exists := m.RoundStatus(roundID(8)); exists{
+		t.Fatal("the INIT of a round below the low-water mark started it")
+	}
+}
+
+func TestForgedEchoDoesNotSilenceInitiator(t *testing.T){
+	m, deliver := setupLoneManager(t, stallingConfig())
+
+	//node2 ECHOes rounds of node1 with huge counters, which node1 never INITed
+	for i := uint64(0); i < 3; i++{
+		forged := InstanceID(nodeID(1), "s", math.MaxUint64 - i)
+		deliver(messages.MsgRbc0{SenderID: nodeID(2), ProtocolID: forged, Type: stageEcho, Payload: "x"})
+		waitRound(t, m, forged, true)
+		waitRound(t, m, forged, false)
+	}
+
+	protocolID := InstanceID(nodeID(1), "s", 1)
+	deliver(messages.MsgRbc0{SenderID: nodeID(1), ProtocolID: protocolID, Type: stageInit, Payload: "x"})
+	waitRound(t, m, protocolID, true)
+}
+
+//wait until the round is accepted
+func waitAccepted(t *testing.T, m *Manager, protocolID string){
+	t.Helper()
+	deadline := time.Now().Add(5*time.Second)
+	for{
+		if status, _ := m.RoundStatus(protocolID); status.Accepted{
+			return
+		}
+		if time.Now().After(deadline){
+			t.Fatalf("round %s was not accepted", protocolID)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+//messages are handled in order: once the INIT of a new round started it, the messages
+//delivered before were handled
+func handled(t *testing.T, m *Manager, deliver func(msg messages.MsgRbc0), protocolID string){
+	t.Helper()
+	deliver(messages.MsgRbc0{SenderID: nodeID(1), ProtocolID: protocolID, Type: stageInit, Payload: "sync"})
+	waitRound(t, m, protocolID, true)
+}
+
+func TestVotesOfNonPeers(t *testing.T){
+	m, deliver := setupLoneManager(t, DefaultConfig())
+	vote := func(senderID string, stage uint32){
+		deliver(messages.MsgRbc0{SenderID: senderID, ProtocolID: roundID(1), Type: stage, Payload: "x"})
+	}
+
+	//t+1 READYs would make node0 READY as well, and 2t+1 accept the round
+	vote("outsider0", stageReady)
+	vote("outsider1", stageReady)
+	vote("outsider2", stageEcho)
+	vote("outsider3", stageEcho)
+	handled(t, m, deliver, roundID(2))
+	if status, _ := m.RoundStatus(roundID(1)); status.Stage != 0{
+		t.Fatalf("votes of nodes outside of the epoch moved node0 to stage %d", status.Stage)
+	}
+
+	vote(nodeID(2), stageReady)
+	vote(nodeID(3), stageReady)
+	waitAccepted(t, m, roundID(1))
+}
+
+func TestInitsOfOutsiders(t *testing.T){
+	m, deliver := setupLoneManager(t, DefaultConfig())
+
+	//nodes outside of the epoch INIT rounds of their own
+	for i := 0; i < 100; i++{
+		outsider := "outsider" + strconv.Itoa(i)
+		deliver(messages.MsgRbc0{SenderID: outsider, ProtocolID: InstanceID(outsider, "s", 1), Type: stageInit, Payload: "x"})
+	}
+	handled(t, m, deliver, roundID(1))
+	for i := 0; i < 100; i++{
+		outsider := "outsider" + strconv.Itoa(i)
+		if _, exists := m.RoundStatus(InstanceID(outsider, "s", 1)); exists{
+			t.Fatalf("the INIT of %s started a round", outsider)
+		}
+	}
+}
+
+func TestLowWaterOfOutsiders(t *testing.T){
+	epoch := membership.Epoch{Number: 1, N: 4, Peers: []peer.ID{"node0", "node1", "node2", "node3"}}
+	lw := retention.NewLowWater(2)
+
+	//initiators which left the epoch each had a round evicted
+	for i := 0; i < 1000; i++{
+		lw.Evict(LowWaterKey("left" + strconv.Itoa(i), "s"), 1)
+		lw.Prune(InEpoch(epoch))
+		if lw.Len() > 0{
+			t.Fatalf("%d marks of initiators outside of the epoch are kept", lw.Len())
+		}
+	}
+
+	for _, p := range epoch.Peers{
+		lw.Evict(LowWaterKey(p.String(), "s"), 1)
+		lw.Evict(LowWaterKey(p.String(), ""), 1)
+	}
+	lw.Prune(InEpoch(epoch))
+	if lw.Len() != 2*len(epoch.Peers) || !lw.IsLate(LowWaterKey(nodeID(1), "s"), 1){
+		t.Fatal("the marks of the peers of the epoch were pruned")
+	}
+}
+
+//the message of stage node i sends in the round protocolID
+func vote(i int, protocolID string, stage uint32, payload string) messages.MsgRbc0{
+	return messages.MsgRbc0{SenderID: nodeID(i), ProtocolID: protocolID, Type: stage, Payload: payload}
+}
+
+//the stage node0 is in in the round, once the messages delivered so far were handled
+func stageAfter(t *testing.T, m *Manager, deliver func(msg messages.MsgRbc0), syncID string, protocolID string) uint32{
+	t.Helper()
+	handled(t, m, deliver, syncID)
+	status, _ := m.RoundStatus(protocolID)
+	return status.Stage
+}
+
+func TestThresholds(t *testing.T){
+	//n=4=3t+1: READY on n-t=3 ECHOs or t+1=2 READYs, ACCEPT on 2t+1=3 READYs
+	m, deliver := setupLoneManager(t, DefaultConfig())
+
+	deliver(vote(1, roundID(1), stageEcho, "x"))
+	deliver(vote(2, roundID(1), stageEcho, "x"))
+	if stage := stageAfter(t, m, deliver, roundID(100), roundID(1)); stage != 0{
+		t.Fatalf("n-t-1 ECHOs moved node0 to stage %d", stage)
+	}
+	deliver(vote(3, roundID(1), stageEcho, "x"))
+	if stage := stageAfter(t, m, deliver, roundID(101), roundID(1)); stage != stageReady{
+		t.Fatalf("n-t ECHOs moved node0 to stage %d, want READY", stage)
+	}
+	//node0's own READY counts
+	deliver(vote(1, roundID(1), stageReady, "x"))
+	if stage := stageAfter(t, m, deliver, roundID(102), roundID(1)); stage != stageReady{
+		t.Fatalf("2t READYs moved node0 to stage %d", stage)
+	}
+	deliver(vote(2, roundID(1), stageReady, "x"))
+	waitAccepted(t, m, roundID(1))
+
+	deliver(vote(1, roundID(2), stageReady, "y"))
+	if stage := stageAfter(t, m, deliver, roundID(103), roundID(2)); stage != 0{
+		t.Fatalf("t READYs moved node0 to stage %d", stage)
+	}
+	deliver(vote(2, roundID(2), stageReady, "y"))
+	waitAccepted(t, m, roundID(2))
+	if status, _ := m.RoundStatus(roundID(2)); status.Payload != "y"{
+		t.Fatalf("round accepted %q, want \"y\"", status.Payload)
+	}
+}
+
+func TestDuplicateVotes(t *testing.T){
+	m, deliver := setupLoneManager(t, DefaultConfig())
+
+	//only the first message of each type from a peer counts, whatever value it carries
+	for _, payload := range []string{"x", "x", "y"}{
+		deliver(vote(1, roundID(1), stageEcho, payload))
+		deliver(vote(1, roundID(1), stageReady, payload))
+	}
+	deliver(vote(2, roundID(1), stageEcho, "x"))
+	if stage := stageAfter(t, m, deliver, roundID(100), roundID(1)); stage != 0{
+		t.Fatalf("repeated votes moved node0 to stage %d", stage)
+	}
+}
+
+func TestEquivocation(t *testing.T){
+	m, deliver := setupLoneManager(t, DefaultConfig())
+
+	//node1 INITs "a" to node0 and "b" to the rest. node0 ECHOes "a",
+	//a second INIT with "b" does not count
+	deliver(vote(1, roundID(1), stageInit, "a"))
+	deliver(vote(1, roundID(1), stageInit, "b"))
+	if stage := stageAfter(t, m, deliver, roundID(100), roundID(1)); stage != stageEcho{
+		t.Fatalf("INIT moved node0 to stage %d, want ECHO", stage)
+	}
+
+	//votes for different values don't add up
+	deliver(vote(1, roundID(1), stageEcho, "a"))
+	deliver(vote(2, roundID(1), stageEcho, "b"))
+	deliver(vote(3, roundID(1), stageEcho, "b"))
+	deliver(vote(2, roundID(1), stageReady, "a"))
+	if stage := stageAfter(t, m, deliver, roundID(101), roundID(1)); stage != stageEcho{
+		t.Fatalf("votes split between values moved node0 to stage %d", stage)
+	}
+
+	//t+1 READYs for "b" make node0 READY for "b" too, and the round is accepted with "b"
+	deliver(vote(3, roundID(1), stageReady, "b"))
+	deliver(vote(1, roundID(1), stageReady, "b"))
+	waitAccepted(t, m, roundID(1))
+	if status, _ := m.RoundStatus(roundID(1)); status.Payload != "b"{
+		t.Fatalf("round accepted %q, want \"b\"", status.Payload)
+	}
+}
+
+//accept the round with payload on node0 by the READYs of node1 and node2
+func acceptRound(t *testing.T, m *Manager, deliver func(msg messages.MsgRbc0), protocolID, payload string){
+	t.Helper()
+	deliver(vote(1, protocolID, stageReady, payload))
+	deliver(vote(2, protocolID, stageReady, payload))
+	waitAccepted(t, m, protocolID)
+}
+
+func TestRoundStatus(t *testing.T){
+	m, deliver := setupLoneManager(t, DefaultConfig())
+
+	if _, exists := m.RoundStatus(roundID(1)); exists{
+		t.Fatal("status of a round node0 knows nothing of")
+	}
+	deliver(vote(1, roundID(1), stageInit, "x"))
+	waitRound(t, m, roundID(1), true)
+	status, _ := m.RoundStatus(roundID(1))
+	expected := RoundStatus{ProtocolID: roundID(1), Stage: stageEcho, Epoch: 1, N: 4, T: 1}
+	if status != expected{
+		t.Fatalf("status %+v, want %+v", status, expected)
+	}
+
+	acceptRound(t, m, deliver, roundID(1), "x")
+	status, _ = m.RoundStatus(roundID(1))
+	if status.Stage != stageAccepted || status.Payload != "x"{
+		t.Fatalf("status %+v of an accepted round", status)
+	}
+
+	//the deadline ends the call, not the round
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := m.BroadcastInstance(ctx, "s", 1, "y")
+	notAccepted, ok := err.(*NotAcceptedError)
+	if !ok || notAccepted.ProtocolID != InstanceID(nodeID(0), "s", 1) || notAccepted.Cause() != context.Canceled{
+		t.Fatalf("got error %v, want a *NotAcceptedError of the instance", err)
+	}
+	if _, exists := m.RoundStatus(notAccepted.ProtocolID); !exists{
+		t.Fatal("the round ended with the call")
+	}
+	if _, err := m.BroadcastInstance(context.Background(), "s", 1, "y"); err == nil{
+		t.Fatal("an instance was broadcast twice")
+	}
+}
+
+func TestSubscribeDeliveries(t *testing.T){
+	cfg := DefaultConfig()
+	cfg.DeliveryLogSize = 2
+	m, deliver := setupLoneManager(t, cfg)
+
+	for cnt := uint64(1); cnt <= 3; cnt++{
+		acceptRound(t, m, deliver, roundID(cnt), fmt.Sprint(cnt))
+	}
+
+	//only the last DeliveryLogSize deliveries are replayed
+	for fromSeq, expected := range map[uint64][]uint64{0: nil, 1: {2, 3}, 3: {3}, 4: nil}{
+		replay, sub := m.SubscribeDeliveries(fromSeq)
+		sub.Close()
+		if len(replay) != len(expected){
+			t.Fatalf("from %d: replayed %d deliveries, want %v", fromSeq, len(replay), expected)
+		}
+		for i, delivery := range replay{
+			if delivery.Seq != expected[i] || delivery.ProtocolID != roundID(expected[i]) || delivery.SenderID != nodeID(1){
+				t.Fatalf("from %d: replayed %+v, want Seq %d", fromSeq, delivery, expected[i])
+			}
+		}
+	}
+
+	//later deliveries follow on the subscription
+	_, sub := m.SubscribeDeliveries(0)
+	defer sub.Close()
+	acceptRound(t, m, deliver, roundID(4), "4")
+	msg, err := sub.Next()
+	if err != nil{
+		t.Fatal(err)
+	}
+	if delivery := msg.(messages.Rbc0Delivery); delivery.Seq != 4 || delivery.Payload != "4"{
+		t.Fatalf("delivered %+v, want Seq 4", delivery)
+	}
+}
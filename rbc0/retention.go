@@ -0,0 +1,197 @@
+package rbc0
+
+import(
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"distry/internal/retention"
+	"distry/membership"
+)
+
+//current round counts of the node, served by expvar under /debug/vars
+var metrics = retention.NewMetrics("rbc0")
+
+//Config bounds how much round state a Manager keeps around
+type Config struct{
+	//accepted rounds are kept as tombstones to ignore their late messages and answer
+	//RoundStatus. They are evicted once older than AcceptedMaxAge
+	//or once there are more than AcceptedMaxCount of them, oldest first.
+	AcceptedMaxAge		time.Duration
+	AcceptedMaxCount	int
+	//rounds which received no message for StalledTimeout are evicted without being accepted
+	StalledTimeout		time.Duration
+	//how often rounds are checked for eviction
+	GCInterval			time.Duration
+	//number of the latest evicted rounds of an initiator remembered one by one to drop their
+	//late messages, the rounds below them are covered by a low-water mark
+	EvictedWindow		int
+	//number of the latest deliveries kept for replay to new subscribers
+	DeliveryLogSize	int
+	//number of deliveries buffered for a SubscribeDeliveries subscriber, it loses the oldest
+	//once it falls further behind. The node buffers as many acs outputs for a SubscribeEpochOutputs one
+	DeliveryBuffer		int
+}
+
+func DefaultConfig() Config{
+	return Config{
+		AcceptedMaxAge:	time.Hour,
+		AcceptedMaxCount:	10000,
+		StalledTimeout:	10*time.Minute,
+		GCInterval:			10*time.Second,
+		EvictedWindow:		1024,
+		DeliveryLogSize:	1024,
+		DeliveryBuffer:	1024,
+	}
+}
+
+//WithDefaults returns cfg with its zero fields set from DefaultConfig: a zero StalledTimeout
+//would evict every round at once, and the managers built on rbc0 use it as their timeout as well
+func (cfg Config) WithDefaults() Config{
+	def := DefaultConfig()
+	if cfg.AcceptedMaxAge <= 0{
+		cfg.AcceptedMaxAge = def.AcceptedMaxAge
+	}
+	if cfg.AcceptedMaxCount <= 0{
+		cfg.AcceptedMaxCount = def.AcceptedMaxCount
+	}
+	if cfg.StalledTimeout <= 0{
+		cfg.StalledTimeout = def.StalledTimeout
+	}
+	if cfg.GCInterval <= 0{
+		cfg.GCInterval = def.GCInterval
+	}
+	if cfg.EvictedWindow <= 0{
+		cfg.EvictedWindow = def.EvictedWindow
+	}
+	if cfg.DeliveryLogSize <= 0{
+		cfg.DeliveryLogSize = def.DeliveryLogSize
+	}
+	if cfg.DeliveryBuffer <= 0{
+		cfg.DeliveryBuffer = def.DeliveryBuffer
+	}
+	return cfg
+}
+
+//ParseProtocolID splits protocolIDs, which are of form INITIATOR_ID + "_" + COUNTER
+//for the rounds of Broadcast and INITIATOR_ID + "_" + STREAM + "-" + COUNTER for the rounds
+//of BroadcastInstance
+func ParseProtocolID(protocolID string) (initiator, stream string, cnt uint64, ok bool){
+	ix := strings.LastIndex(protocolID, "_")
+	if ix < 0{
+		return "", "", 0, false
+	}
+	initiator, counter := protocolID[:ix], protocolID[ix+1:]
+	if ix = strings.LastIndex(counter, "-"); ix >= 0{
+		stream, counter = counter[:ix], counter[ix+1:]
+	}
+	cnt, err := strconv.ParseUint(counter, 10, 64)
+	if err != nil{
+		return "", "", 0, false
+	}
+	return initiator, stream, cnt, true
+}
+
+//LowWaterKey returns the key of the low-water mark of a round: counters only grow within
+//the rounds of one initiator in one stream
+func LowWaterKey(initiator, stream string) string{
+	return initiator + "_" + stream
+}
+
+//InEpoch returns whether the initiator of a low-water key is a peer of epoch.
+//the rounds of initiators outside of the epoch aren't started, so their marks are pruned.
+func InEpoch(epoch membership.Epoch) func(key string) bool{
+	peers := make(map[string]struct{}, len(epoch.Peers))
+	for _, p := range epoch.Peers{
+		peers[p.String()] = struct{}{}
+	}
+	return func(key string) bool{
+		ix := strings.LastIndex(key, "_")
+		if ix < 0{
+			return false
+		}
+		_, isPeer := peers[key[:ix]]
+		return isPeer
+	}
+}
+
+//a message for a round this node doesn't know is late if its round was evicted
+func (m *Manager) isLate(protocolID string) bool{
+	initiator, stream, cnt, ok := ParseProtocolID(protocolID)
+	if !ok{
+		return true
+	}
+	return m.lowWater.IsLate(LowWaterKey(initiator, stream), cnt)
+}
+
+//IsOutsider tells whether the initiator of the round protocolID is no peer of epoch.
+//rounds are only started for initiators of the current epoch, anyone else could make
+//a node keep state for as many rounds as it likes.
+func IsOutsider(epoch membership.Epoch, protocolID string) bool{
+	initiator, _, _, ok := ParseProtocolID(protocolID)
+	if !ok{
+		return true
+	}
+	for _, p := range epoch.Peers{
+		if p.String() == initiator{
+			return false
+		}
+	}
+	return true
+}
+
+//evict accepted rounds which are too old or too many and rounds which stalled.
+//evicted rounds are remembered in the low-water marks, so their late messages
+//don't start them anew.
+func (m *Manager) gc(now time.Time){
+	evictedAccepted := retention.Expired(now, m.cfg.AcceptedMaxAge, m.cfg.AcceptedMaxCount, len(m.acceptedOrder), func(i int) time.Time{
+		return m.roundInfoMap[m.acceptedOrder[i]].acceptedAt
+	})
+	for _, protocolID := range m.acceptedOrder[:evictedAccepted]{
+		m.evict(protocolID)
+	}
+	m.acceptedOrder = m.acceptedOrder[evictedAccepted:]
+
+	evictedStalled := 0
+	for protocolID, ri := range m.roundInfoMap{
+		if ri.localStage != stageAccepted && retention.Stalled(now, ri.lastActivity, m.cfg.StalledTimeout){
+			m.logger.Info("evicting stalled rbc0 round",
+				zap.String("protocolID", protocolID),
+				zap.Uint32("localStage", ri.localStage),
+			)
+			m.evict(protocolID)
+			evictedStalled++
+		}
+	}
+
+	m.lowWater.Prune(InEpoch(m.membershipManager.Current()))
+
+	metrics.Add("rounds_evicted_accepted", int64(evictedAccepted))
+	metrics.Add("rounds_evicted_stalled", int64(evictedStalled))
+	m.updateMetrics()
+}
+
+//only rounds with the initiator's INIT or accepted ones are remembered as evicted:
+//anyone can send an ECHO of a round the initiator never started
+func (m *Manager) evict(protocolID string){
+	ri := m.roundInfoMap[protocolID]
+	delete(m.roundInfoMap, protocolID)
+	if ri.initDigest == "" && ri.localStage != stageAccepted{
+		return
+	}
+
+	initiator, stream, cnt, ok := ParseProtocolID(protocolID)
+	if ok{
+		m.lowWater.Evict(LowWaterKey(initiator, stream), cnt)
+	}
+}
+
+//publish the current round counts
+func (m *Manager) updateMetrics(){
+	accepted := len(m.acceptedOrder)
+	metrics.SetGauge("rounds_active", len(m.roundInfoMap) - accepted)
+	metrics.SetGauge("rounds_accepted", accepted)
+	metrics.SetGauge("low_water_marks", m.lowWater.Len())
+}
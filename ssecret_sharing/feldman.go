@@ -0,0 +1,147 @@
+package ssecret_sharing
+
+import (
+	"io"
+
+	"filippo.io/edwards25519"
+	"github.com/pkg/errors"
+)
+
+//Feldman's verifiable secret sharing (a practical scheme for non-interactive verifiable secret sharing, 1987).
+//the shares of Manager are bare points, a holder can't tell whether the dealer handed out
+//points of a single polynomial of degree k-1. here the dealer also publishes the commitments
+//C_i = a_i*B to the coefficients a_i of the polynomial, B being the base point of the prime
+//order group of edwards25519. since B*f(x) = sum(C_i * x^i), anyone can check a share against
+//the commitments without learning anything beyond the public key C_0 = B*secret.
+
+//ErrInvalidShare is returned for a share which does not match the commitments
+var ErrInvalidShare = errors.New("share does not match the commitments")
+
+//Commitments are the feldman commitments to the coefficients of a sharing polynomial,
+//Commitments[0] commits to the secret
+type Commitments []*edwards25519.Point
+
+//Commit commits to the coefficients of the polynomial
+func (poly ScalarPolynomial) Commit() Commitments {
+	commitments := make(Commitments, len(poly))
+	for i, coef := range poly {
+		commitments[i] = new(edwards25519.Point).ScalarBaseMult(coef)
+	}
+	return commitments
+}
+
+//SplitVerifiable shares secret among n holders, any k of which can reconstruct it,
+//and returns the commitments the dealer publishes along with the shares
+func SplitVerifiable(secret *edwards25519.Scalar, k, n int, rand io.Reader) (Commitments, []ScalarShare, error) {
+	if k < 1 || k > n {
+		return nil, nil, errors.Errorf("can't share among %d holders with threshold %d", n, k)
+	}
+	poly, err := NewScalarPolynomial(secret, k-1, rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	return poly.Commit(), poly.Shares(n), nil
+}
+
+//Threshold is the number of shares needed to reconstruct the secret
+func (c Commitments) Threshold() int {
+	return len(c)
+}
+
+//PublicKey is the base point multiplied by the secret
+func (c Commitments) PublicKey() *edwards25519.Point {
+	return c[0]
+}
+
+//Eval evaluates the polynomial at x in the exponent: the base point multiplied by f(x)
+func (c Commitments) Eval(x uint32) *edwards25519.Point {
+	xs := ScalarFromUint(x)
+	power := ScalarFromUint(1)
+	powers := make([]*edwards25519.Scalar, len(c))
+	for i := range c {
+		powers[i] = edwards25519.NewScalar().Set(power)
+		power.Multiply(power, xs)
+	}
+	return new(edwards25519.Point).VarTimeMultiScalarMult(powers, c)
+}
+
+//Verify returns ErrInvalidShare if share is not the value of the committed polynomial at its index
+func (c Commitments) Verify(share ScalarShare) error {
+	if share.Index == 0 {
+		return errors.New("share index 0 would reveal the secret")
+	}
+	if share.Value == nil {
+		return ErrInvalidShare
+	}
+	if new(edwards25519.Point).ScalarBaseMult(share.Value).Equal(c.Eval(share.Index)) != 1 {
+		return ErrInvalidShare
+	}
+	return nil
+}
+
+//Add adds the committed polynomials, the result commits to the sum of the secrets.
+//both must have the same threshold.
+func (c Commitments) Add(other Commitments) (Commitments, error) {
+	if len(c) != len(other) {
+		return nil, errors.Errorf("can't add commitments of thresholds %d and %d", len(c), len(other))
+	}
+	sum := make(Commitments, len(c))
+	for i := range c {
+		sum[i] = new(edwards25519.Point).Add(c[i], other[i])
+	}
+	return sum, nil
+}
+
+//Bytes encodes the commitments as 32 bytes per commitment
+func (c Commitments) Bytes() []byte {
+	raw := make([]byte, 0, 32*len(c))
+	for _, commitment := range c {
+		raw = append(raw, commitment.Bytes()...)
+	}
+	return raw
+}
+
+//DecodeCommitments decodes the encoding of Bytes
+func DecodeCommitments(raw []byte) (Commitments, error) {
+	if len(raw) == 0 || len(raw)%32 != 0 {
+		return nil, errors.Errorf("commitments of %d bytes", len(raw))
+	}
+	commitments := make(Commitments, len(raw)/32)
+	for i := range commitments {
+		p, err := new(edwards25519.Point).SetBytes(raw[32*i : 32*(i+1)])
+		if err != nil {
+			return nil, errors.Wrapf(err, "decoding commitment %d", i)
+		}
+		commitments[i] = p
+	}
+	return commitments, nil
+}
+
+//ReconstructVerifiable reconstructs the secret from the shares which match the commitments,
+//the rest are rejected. indexes of the rejected shares are returned along with the secret,
+//there must be at least Threshold valid shares.
+func ReconstructVerifiable(c Commitments, shares []ScalarShare) (*edwards25519.Scalar, []uint32, error) {
+	valid := make([]ScalarShare, 0, len(shares))
+	rejected := make([]uint32, 0)
+	seen := make(map[uint32]bool, len(shares))
+	for _, share := range shares {
+		if seen[share.Index] {
+			continue
+		}
+		if err := c.Verify(share); err != nil {
+			rejected = append(rejected, share.Index)
+			continue
+		}
+		seen[share.Index] = true
+		valid = append(valid, share)
+	}
+	if len(valid) < c.Threshold() {
+		return nil, rejected, errors.Errorf("%d valid shares, %d are needed (%d rejected)", len(valid), c.Threshold(), len(rejected))
+	}
+
+	secret, err := InterpolateScalar(valid[:c.Threshold()])
+	if err != nil {
+		return nil, rejected, err
+	}
+	return secret, rejected, nil
+}
@@ -1,4 +1,4 @@
-package main
+package ssecret_sharing
 
 //here basic operations on polynomials over the galois field 2^8 are implemented
 
@@ -37,6 +37,12 @@ func mul_costly(a, b byte) byte {
 }
 
 
+//the tables are filled when the package is loaded, so concurrent callers never race on them
+func init() {
+	init_tables()
+	tables_initialised = true
+}
+
 //use generator 2 to init log and exp tables
 func init_tables() {
 	x := byte(1)
@@ -1,10 +1,7 @@
-package main
+package ssecret_sharing
 import (
-	"fmt"
-	"math/rand"
 	_"strconv"
 	_"sync"
-	"time"
 
 	_"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -85,46 +82,3 @@ func (m *Manager) interpolate(points []point) byte {
 
 	return ss
 }
-
-func test(k, n byte, times int) {
-	for t:=0; t < times; t++ {
-		rand.Seed(time.Now().UnixNano())
-
-		poly := make([]byte, k) //create a random polynom
-		for i := range(poly){
-			poly[i] = byte(rand.Intn(256))
-		}
-
-		secret := poly[0] //note its secret
-		m := NewManager(k, n, secret)
-		m.poly = poly
-
-		points := make([]point, m.n) //evaluate polynom at points 1 to n
-		for ix := range(points){ //points go from 1 onwards, because poly(0) = secret !
-			points[ix].x = byte(1+ix)
-			points[ix].y = m.poly_eval(points[ix].x)
-		}
-
-		//create random subset of points to use in interpolation
-		points_subset := make([]point, m.k)
-		for i, point_ix := range rand.Perm(int(m.n)){
-			if i == int(m.k) {
-				break
-			}
-			points_subset[i] = points[point_ix]
-		}
-
-		ss := m.interpolate(points_subset) //interpolate the secret
-		if ss != secret {
-			panic("interpolated secret does not equal original")
-		}
-	}
-}
-
-
-func main() {
-	fmt.Println(mul(5, 7))
-
-	k, n := byte(5), byte(15)
-	test(k, n, 100000)
-}
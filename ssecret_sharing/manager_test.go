@@ -0,0 +1,119 @@
+package ssecret_sharing
+import (
+	crand "crypto/rand"
+	"math/rand"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519"
+)
+
+func test(k, n byte, times int) {
+	for t:=0; t < times; t++ {
+		rand.Seed(time.Now().UnixNano())
+
+		poly := make([]byte, k) //create a random polynom
+		for i := range(poly){
+			poly[i] = byte(rand.Intn(256))
+		}
+
+		secret := poly[0] //note its secret
+		m := NewManager(k, n, secret)
+		m.poly = poly
+
+		points := make([]point, m.n) //evaluate polynom at points 1 to n
+		for ix := range(points){ //points go from 1 onwards, because poly(0) = secret !
+			points[ix].x = byte(1+ix)
+			points[ix].y = m.poly_eval(points[ix].x)
+		}
+
+		//create random subset of points to use in interpolation
+		points_subset := make([]point, m.k)
+		for i, point_ix := range rand.Perm(int(m.n)){
+			if i == int(m.k) {
+				break
+			}
+			points_subset[i] = points[point_ix]
+		}
+
+		ss := m.interpolate(points_subset) //interpolate the secret
+		if ss != secret {
+			panic("interpolated secret does not equal original")
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	k, n := byte(5), byte(15)
+	test(k, n, 1000)
+}
+
+func TestInterpolateScalar(t *testing.T) {
+	secret, err := RandomScalar(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares, err := SplitScalar(secret, 3, 7, crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, subset := range [][]int{{0, 1, 2}, {6, 3, 1}, {4, 5, 6, 0}} {
+		picked := make([]ScalarShare, len(subset))
+		for i, ix := range subset {
+			picked[i] = shares[ix]
+		}
+		interpolated, err := InterpolateScalar(picked)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if interpolated.Equal(secret) != 1 {
+			t.Fatalf("shares %v interpolated into another secret", subset)
+		}
+	}
+
+	interpolated, err := InterpolateScalar(shares[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if interpolated.Equal(secret) == 1 {
+		t.Fatal("2 shares of a threshold of 3 revealed the secret")
+	}
+}
+
+func TestVerifiable(t *testing.T) {
+	secret, err := RandomScalar(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitments, shares, err := SplitVerifiable(secret, 3, 7, crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, share := range shares {
+		if err := commitments.Verify(share); err != nil {
+			t.Fatalf("share %d: %v", share.Index, err)
+		}
+	}
+
+	//a dealer handing out a point off the polynomial gets caught
+	bad := ScalarShare{Index: shares[1].Index, Value: edwards25519.NewScalar().Add(shares[1].Value, ScalarFromUint(1))}
+	if err := commitments.Verify(bad); err != ErrInvalidShare {
+		t.Fatalf("bad share verified: %v", err)
+	}
+
+	reconstructed, rejected, err := ReconstructVerifiable(commitments, []ScalarShare{shares[0], bad, shares[4], shares[6]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reconstructed.Equal(secret) != 1 {
+		t.Fatal("reconstructed another secret")
+	}
+	if len(rejected) != 1 || rejected[0] != bad.Index {
+		t.Fatalf("rejected %v, expected [%d]", rejected, bad.Index)
+	}
+
+	if _, _, err := ReconstructVerifiable(commitments, []ScalarShare{shares[0], bad, shares[4]}); err == nil {
+		t.Fatal("reconstructed from 2 valid shares of a threshold of 3")
+	}
+}
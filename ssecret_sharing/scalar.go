@@ -0,0 +1,146 @@
+package ssecret_sharing
+
+import (
+	"encoding/binary"
+	"io"
+
+	"filippo.io/edwards25519"
+	"github.com/pkg/errors"
+)
+
+//Shamir's secret sharing of scalars of the edwards25519 group (integers modulo its prime order).
+//unlike the byte sharing of Manager, the shares can be put in the exponent: multiplying a point
+//by the shares and interpolating the products gives the point multiplied by the secret,
+//which threshold cryptography is built on.
+
+//ScalarShare is the value of the sharing polynomial at Index, which is never 0
+type ScalarShare struct {
+	Index uint32
+	Value *edwards25519.Scalar
+}
+
+//ScalarPolynomial holds the coefficients of a polynomial, the secret is the constant term
+type ScalarPolynomial []*edwards25519.Scalar
+
+//RandomScalar returns a uniformly random scalar
+func RandomScalar(rand io.Reader) (*edwards25519.Scalar, error) {
+	var buf [64]byte
+	if _, err := io.ReadFull(rand, buf[:]); err != nil {
+		return nil, errors.Wrap(err, "reading randomness")
+	}
+	return edwards25519.NewScalar().SetUniformBytes(buf[:])
+}
+
+//ScalarFromUint returns x as a scalar
+func ScalarFromUint(x uint32) *edwards25519.Scalar {
+	var buf [32]byte
+	binary.LittleEndian.PutUint32(buf[:], x)
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(buf[:])
+	if err != nil {
+		panic(err) //can't happen, x is far below the order
+	}
+	return s
+}
+
+//NewScalarPolynomial creates a random polynomial of degree with secret as its constant term
+func NewScalarPolynomial(secret *edwards25519.Scalar, degree int, rand io.Reader) (ScalarPolynomial, error) {
+	poly := make(ScalarPolynomial, degree+1)
+	poly[0] = edwards25519.NewScalar().Set(secret)
+	for i := 1; i <= degree; i++ {
+		coef, err := RandomScalar(rand)
+		if err != nil {
+			return nil, err
+		}
+		poly[i] = coef
+	}
+	return poly, nil
+}
+
+//Eval evaluates the polynomial at x with horner's rule
+func (poly ScalarPolynomial) Eval(x uint32) *edwards25519.Scalar {
+	xs := ScalarFromUint(x)
+	value := edwards25519.NewScalar()
+	for i := len(poly) - 1; i >= 0; i-- {
+		value.MultiplyAdd(value, xs, poly[i])
+	}
+	return value
+}
+
+//Shares evaluates the polynomial at 1 to n
+func (poly ScalarPolynomial) Shares(n int) []ScalarShare {
+	shares := make([]ScalarShare, n)
+	for i := range shares {
+		shares[i] = ScalarShare{Index: uint32(i + 1), Value: poly.Eval(uint32(i + 1))}
+	}
+	return shares
+}
+
+//SplitScalar shares secret among n holders, any k of which can reconstruct it
+func SplitScalar(secret *edwards25519.Scalar, k, n int, rand io.Reader) ([]ScalarShare, error) {
+	if k < 1 || k > n {
+		return nil, errors.Errorf("can't share among %d holders with threshold %d", n, k)
+	}
+	poly, err := NewScalarPolynomial(secret, k-1, rand)
+	if err != nil {
+		return nil, err
+	}
+	return poly.Shares(n), nil
+}
+
+//LagrangeAtZero returns the lagrange basis coefficient of index i among indexes, evaluated at 0.
+//as in interpolate, only the constant term of the basis polynomials is ever needed.
+func LagrangeAtZero(indexes []uint32, i uint32) (*edwards25519.Scalar, error) {
+	num := ScalarFromUint(1)
+	den := ScalarFromUint(1)
+	found := false
+	for _, j := range indexes {
+		if j == i {
+			found = true
+			continue
+		}
+		xj := ScalarFromUint(j)
+		num.Multiply(num, xj)
+		den.Multiply(den, edwards25519.NewScalar().Subtract(xj, ScalarFromUint(i)))
+	}
+	if !found {
+		return nil, errors.Errorf("index %d is not among the indexes", i)
+	}
+	return num.Multiply(num, edwards25519.NewScalar().Invert(den)), nil
+}
+
+//CheckIndexes returns an error if indexes contain 0 or repeat
+func CheckIndexes(indexes []uint32) error {
+	seen := make(map[uint32]bool, len(indexes))
+	for _, i := range indexes {
+		if i == 0 {
+			return errors.New("share index 0 would reveal the secret")
+		}
+		if seen[i] {
+			return errors.Errorf("share index %d repeats", i)
+		}
+		seen[i] = true
+	}
+	return nil
+}
+
+//InterpolateScalar reconstructs the secret from shares,
+//which must be at least the threshold they were split with
+func InterpolateScalar(shares []ScalarShare) (*edwards25519.Scalar, error) {
+	indexes := make([]uint32, len(shares))
+	for i, share := range shares {
+		indexes[i] = share.Index
+	}
+	if err := CheckIndexes(indexes); err != nil {
+		return nil, err
+	}
+
+	secret := edwards25519.NewScalar()
+	for _, share := range shares {
+		coef, err := LagrangeAtZero(indexes, share.Index)
+		if err != nil {
+			return nil, err
+		}
+		secret.MultiplyAdd(coef, share.Value, secret)
+	}
+	return secret, nil
+}